@@ -0,0 +1,181 @@
+package vacuum
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// CarpetMode controls how the vacuum detects carpet, it increases suction when the current
+// drawn by the main brush indicates it is on carpet.
+type CarpetMode struct {
+	// Enabled increases suction on carpet.
+	Enabled bool
+	// CurrentLow, CurrentHigh and CurrentIntegral are the main brush current thresholds
+	// used to detect carpet.
+	CurrentLow      int
+	CurrentHigh     int
+	CurrentIntegral int
+	// StallTime is the time, in seconds, the current must be exceeded before carpet is detected.
+	StallTime int
+}
+
+// DefaultCarpetMode returns the factory carpet detection settings.
+func DefaultCarpetMode() CarpetMode {
+	return CarpetMode{
+		Enabled:         true,
+		CurrentLow:      400,
+		CurrentHigh:     500,
+		CurrentIntegral: 450,
+		StallTime:       10,
+	}
+}
+
+type carpetMode struct {
+	Enable          int `json:"enable"`
+	CurrentLow      int `json:"current_low"`
+	CurrentHigh     int `json:"current_high"`
+	CurrentIntegral int `json:"current_integral"`
+	StallTime       int `json:"stall_time"`
+}
+
+// GetCarpetMode retrieves the carpet detection settings.
+func (v *Vacuum) GetCarpetMode() (*CarpetMode, error) {
+	rsp := make([]carpetMode, 0)
+
+	err := v.do("get_carpet_mode", nil, &rsp)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rsp) != 1 {
+		return nil, ErrUnexpectedResponse
+	}
+
+	return &CarpetMode{
+		Enabled:         rsp[0].Enable == 1,
+		CurrentLow:      rsp[0].CurrentLow,
+		CurrentHigh:     rsp[0].CurrentHigh,
+		CurrentIntegral: rsp[0].CurrentIntegral,
+		StallTime:       rsp[0].StallTime,
+	}, nil
+}
+
+// SetCarpetMode sets the carpet detection settings. Thresholds which are not set are taken
+// from DefaultCarpetMode, so only Enabled needs to be set to toggle increased suction.
+func (v *Vacuum) SetCarpetMode(mode CarpetMode) error {
+	d := DefaultCarpetMode()
+
+	if mode.CurrentLow == 0 {
+		mode.CurrentLow = d.CurrentLow
+	}
+
+	if mode.CurrentHigh == 0 {
+		mode.CurrentHigh = d.CurrentHigh
+	}
+
+	if mode.CurrentIntegral == 0 {
+		mode.CurrentIntegral = d.CurrentIntegral
+	}
+
+	if mode.StallTime == 0 {
+		mode.StallTime = d.StallTime
+	}
+
+	if mode.CurrentLow < 0 || mode.CurrentLow >= mode.CurrentHigh {
+		return errors.New("current low must be positive and less than current high")
+	}
+
+	if mode.CurrentIntegral < 0 || mode.StallTime < 0 {
+		return errors.New("current integral and stall time must be positive")
+	}
+
+	enable := 0
+	if mode.Enabled {
+		enable = 1
+	}
+
+	p, err := json.Marshal([]carpetMode{{
+		Enable:          enable,
+		CurrentLow:      mode.CurrentLow,
+		CurrentHigh:     mode.CurrentHigh,
+		CurrentIntegral: mode.CurrentIntegral,
+		StallTime:       mode.StallTime,
+	}})
+	if err != nil {
+		return err
+	}
+
+	return v.doParams("set_carpet_mode", p)
+}
+
+// CarpetCleanMode controls how the vacuum treats carpet when mopping.
+type CarpetCleanMode int
+
+const (
+	// CarpetCleanModeAvoid avoids carpet when the mop is attached.
+	CarpetCleanModeAvoid CarpetCleanMode = 0
+	// CarpetCleanModeRise lifts the mop when on carpet.
+	CarpetCleanModeRise CarpetCleanMode = 1
+	// CarpetCleanModeIgnore treats carpet the same as other floors.
+	CarpetCleanModeIgnore CarpetCleanMode = 2
+)
+
+func (c CarpetCleanMode) String() string {
+	switch c {
+	case CarpetCleanModeAvoid:
+		return "Avoid"
+	case CarpetCleanModeRise:
+		return "Rise"
+	case CarpetCleanModeIgnore:
+		return "Ignore"
+	}
+
+	return fmt.Sprintf("CarpetCleanMode(%d)", int(c))
+}
+
+type carpetCleanMode struct {
+	Mode CarpetCleanMode `json:"carpet_clean_mode"`
+}
+
+// GetCarpetCleanMode retrieves how carpet is treated when mopping. This is only supported by
+// newer models, ErrNotSupported is returned otherwise.
+func (v *Vacuum) GetCarpetCleanMode() (CarpetCleanMode, error) {
+	err := v.requireMethod("get_carpet_clean_mode")
+	if err != nil {
+		return 0, err
+	}
+
+	rsp := make([]carpetCleanMode, 0)
+
+	err = v.do("get_carpet_clean_mode", nil, &rsp)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(rsp) != 1 {
+		return 0, ErrUnexpectedResponse
+	}
+
+	return rsp[0].Mode, nil
+}
+
+// SetCarpetCleanMode sets how carpet is treated when mopping. This is only supported by
+// newer models, ErrNotSupported is returned otherwise.
+func (v *Vacuum) SetCarpetCleanMode(mode CarpetCleanMode) error {
+	if mode < CarpetCleanModeAvoid || mode > CarpetCleanModeIgnore {
+		return fmt.Errorf("unknown carpet clean mode %s", mode)
+	}
+
+	err := v.requireMethod("set_carpet_clean_mode")
+	if err != nil {
+		return err
+	}
+
+	p, err := json.Marshal([]carpetCleanMode{{Mode: mode}})
+	if err != nil {
+		return err
+	}
+
+	return v.doParams("set_carpet_clean_mode", p)
+}
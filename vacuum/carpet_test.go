@@ -0,0 +1,87 @@
+package vacuum
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVacuum_GetCarpetMode(t *testing.T) {
+	t.Parallel()
+
+	v := &Vacuum{
+		client: &mockClient{
+			rsp: []byte(`
+				{
+					"result": [{
+							"enable": 1,
+							"current_integral": 450,
+							"current_high": 500,
+							"current_low": 400,
+							"stall_time": 10
+						}
+					],
+					"id": 1
+				}`,
+			),
+		},
+	}
+
+	got, err := v.GetCarpetMode()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultCarpetMode(), *got)
+}
+
+func TestVacuum_SetCarpetMode(t *testing.T) {
+	t.Parallel()
+
+	c := &mockClient{}
+	v := &Vacuum{client: c}
+
+	err := v.SetCarpetMode(CarpetMode{Enabled: false})
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{
+		"enable": 0,
+		"current_integral": 450,
+		"current_high": 500,
+		"current_low": 400,
+		"stall_time": 10
+	}]`, string(c.reqs[0].Params))
+
+	err = v.SetCarpetMode(CarpetMode{Enabled: true, CurrentLow: 600, CurrentHigh: 500})
+	assert.Error(t, err)
+	assert.Len(t, c.reqs, 1)
+}
+
+func TestVacuum_CarpetCleanMode(t *testing.T) {
+	t.Parallel()
+
+	c := &mockClient{
+		rsps: map[string][][]byte{
+			"get_carpet_clean_mode": {[]byte(`{"result": [{"carpet_clean_mode": 1}], "id": 1}`)},
+		},
+	}
+	v := &Vacuum{client: c, modelName: "roborock.vacuum.a27"}
+
+	got, err := v.GetCarpetCleanMode()
+	require.NoError(t, err)
+	assert.Equal(t, CarpetCleanModeRise, got)
+
+	require.NoError(t, v.SetCarpetCleanMode(CarpetCleanModeIgnore))
+	assert.JSONEq(t, `[{"carpet_clean_mode": 2}]`, string(c.reqs[1].Params))
+}
+
+func TestVacuum_CarpetCleanMode_NotSupported(t *testing.T) {
+	t.Parallel()
+
+	v := &Vacuum{
+		client: &mockClient{
+			rsp: []byte(`{"error": {"code": -32601, "message": "Method not found."}, "id": 1}`),
+		},
+	}
+
+	_, err := v.GetCarpetCleanMode()
+	assert.True(t, errors.Is(err, ErrNotSupported))
+}
@@ -1,5 +1,7 @@
 package vacuum
 
+import "fmt"
+
 type StatusCode int
 
 const (
@@ -25,6 +27,37 @@ const (
 	StatusCodeFullyCharged  StatusCode = 100
 )
 
+var statusCodeNames = map[StatusCode]string{
+	StatusCodeUnknown:       "Unknown",
+	StatusCodeInitiating:    "Initiating",
+	StatusCodeSleeping:      "Sleeping",
+	StatusCodeIdle:          "Idle",
+	StatusCodeRemoteControl: "RemoteControl",
+	StatusCodeCleaning:      "Cleaning",
+	StatusCodeReturningDock: "ReturningDock",
+	StatusCodeManualMode:    "ManualMode",
+	StatusCodeCharging:      "Charging",
+	StatusCodeChargingError: "ChargingError",
+	StatusCodePaused:        "Paused",
+	StatusCodeSpotCleaning:  "SpotCleaning",
+	StatusCodeInError:       "InError",
+	StatusCodeShuttingDown:  "ShuttingDown",
+	StatusCodeUpdating:      "Updating",
+	StatusCodeDocking:       "Docking",
+	StatusCodeGoTo:          "GoTo",
+	StatusCodeZoneClean:     "ZoneClean",
+	StatusCodeRoomClean:     "RoomClean",
+	StatusCodeFullyCharged:  "FullyCharged",
+}
+
+func (c StatusCode) String() string {
+	if name, ok := statusCodeNames[c]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("StatusCode(%d)", int(c))
+}
+
 type ErrorCode int
 
 const (
@@ -53,10 +86,54 @@ const (
 	ErrorCodeChargeSensorProblem        ErrorCode = 22
 	ErrorCodeDockProblem                ErrorCode = 23
 	ErrorCodeInvisibleWallDetected      ErrorCode = 24
+	ErrorCodeWallSensorDirty            ErrorCode = 26
+	ErrorCodeVibraRiseJammed            ErrorCode = 27
+	ErrorCodeRobotOnCarpet              ErrorCode = 28
 	ErrorCodeBinFull                    ErrorCode = 254
 	ErrorCodeInternalError              ErrorCode = 255
 )
 
+var errorCodeNames = map[ErrorCode]string{
+	ErrorCodeUnknown:                    "Unknown",
+	ErrorCodeNoError:                    "NoError",
+	ErrorCodeLaserSensorFault:           "LaserSensorFault",
+	ErrorCodeCollisionSensorFault:       "CollisionSensorFault",
+	ErrorCodeWheelFloating:              "WheelFloating",
+	ErrorCodeCliffSensorFault:           "CliffSensorFault",
+	ErrorCodeMainBrushBlocked:           "MainBrushBlocked",
+	ErrorCodeSideBrushBlocked:           "SideBrushBlocked",
+	ErrorCodeWheelBlocked:               "WheelBlocked",
+	ErrorCodeDeviceStuck:                "DeviceStuck",
+	ErrorCodeDustBinMissing:             "DustBinMissing",
+	ErrorCodeFilterBlocked:              "FilterBlocked",
+	ErrorCodeMagneticFieldDetected:      "MagneticFieldDetected",
+	ErrorCodeLowBattery:                 "LowBattery",
+	ErrorCodeChargingProblem:            "ChargingProblem",
+	ErrorCodeBatteryFailure:             "BatteryFailure",
+	ErrorCodeWallSensorFault:            "WallSensorFault",
+	ErrorCodeUnevenSurface:              "UnevenSurface",
+	ErrorCodeSideBrushFailure:           "SideBrushFailure",
+	ErrorCodeSuctionFanFailure:          "SuctionFanFailure",
+	ErrorCodeUnpoweredChargingStation:   "UnpoweredChargingStation",
+	ErrorCodeLaserPressureSensorProblem: "LaserPressureSensorProblem",
+	ErrorCodeChargeSensorProblem:        "ChargeSensorProblem",
+	ErrorCodeDockProblem:                "DockProblem",
+	ErrorCodeInvisibleWallDetected:      "InvisibleWallDetected",
+	ErrorCodeWallSensorDirty:            "WallSensorDirty",
+	ErrorCodeVibraRiseJammed:            "VibraRiseJammed",
+	ErrorCodeRobotOnCarpet:              "RobotOnCarpet",
+	ErrorCodeBinFull:                    "BinFull",
+	ErrorCodeInternalError:              "InternalError",
+}
+
+func (c ErrorCode) String() string {
+	if name, ok := errorCodeNames[c]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("ErrorCode(%d)", int(c))
+}
+
 type FeatureCode int
 
 const (
@@ -74,3 +151,59 @@ const (
 	FeatureCodeAnalysis            FeatureCode = 124
 	FeatureCodeRemote              FeatureCode = 125
 )
+
+var featureCodeNames = map[FeatureCode]string{
+	FeatureCodeClean:               "Clean",
+	FeatureCodeFSEndPoint:          "FSEndPoint",
+	FeatureCodeAutoSplitSegments:   "AutoSplitSegments",
+	FeatureCodeDeleteMap:           "DeleteMap",
+	FeatureCodeOrderSegmentClean:   "OrderSegmentClean",
+	FeatureCodeSpotClean:           "SpotClean",
+	FeatureCodeMapSegment:          "MapSegment",
+	FeatureCodeLEDStatusSwitch:     "LEDStatusSwitch",
+	FeatureCodeMultiFloorSupported: "MultiFloorSupported",
+	FeatureCodeFetchTimerSummary:   "FetchTimerSummary",
+	FeatureCodeOrdersClean:         "OrdersClean",
+	FeatureCodeAnalysis:            "Analysis",
+	FeatureCodeRemote:              "Remote",
+}
+
+func (c FeatureCode) String() string {
+	if name, ok := featureCodeNames[c]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("FeatureCode(%d)", int(c))
+}
+
+type DockErrorCode int
+
+const (
+	DockErrorCodeOK                        DockErrorCode = 0
+	DockErrorCodeDuctBlockage              DockErrorCode = 34
+	DockErrorCodeWaterEmpty                DockErrorCode = 38
+	DockErrorCodeWasteWaterTankFull        DockErrorCode = 39
+	DockErrorCodeMaintenanceBrushJammed    DockErrorCode = 42
+	DockErrorCodeDirtyTankLatchOpen        DockErrorCode = 44
+	DockErrorCodeNoDustbin                 DockErrorCode = 46
+	DockErrorCodeCleaningTankFullOrBlocked DockErrorCode = 53
+)
+
+var dockErrorCodeNames = map[DockErrorCode]string{
+	DockErrorCodeOK:                        "OK",
+	DockErrorCodeDuctBlockage:              "DuctBlockage",
+	DockErrorCodeWaterEmpty:                "WaterEmpty",
+	DockErrorCodeWasteWaterTankFull:        "WasteWaterTankFull",
+	DockErrorCodeMaintenanceBrushJammed:    "MaintenanceBrushJammed",
+	DockErrorCodeDirtyTankLatchOpen:        "DirtyTankLatchOpen",
+	DockErrorCodeNoDustbin:                 "NoDustbin",
+	DockErrorCodeCleaningTankFullOrBlocked: "CleaningTankFullOrBlocked",
+}
+
+func (c DockErrorCode) String() string {
+	if name, ok := dockErrorCodeNames[c]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("DockErrorCode(%d)", int(c))
+}
@@ -0,0 +1,47 @@
+package vacuum
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodes_String(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		code fmt.Stringer
+		want string
+	}{
+		"StatusCode": {
+			code: StatusCodeReturningDock,
+			want: "ReturningDock",
+		},
+		"UnknownStatusCode": {
+			code: StatusCode(99),
+			want: "StatusCode(99)",
+		},
+		"ErrorCode": {
+			code: ErrorCodeMainBrushBlocked,
+			want: "MainBrushBlocked",
+		},
+		"FeatureCode": {
+			code: FeatureCodeMapSegment,
+			want: "MapSegment",
+		},
+		"DockErrorCode": {
+			code: DockErrorCodeNoDustbin,
+			want: "NoDustbin",
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, tt.code.String())
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package vacuum
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type ConsumableKind string
+
+const (
+	ConsumableMainBrush ConsumableKind = "main_brush_work_time"
+	ConsumableSideBrush ConsumableKind = "side_brush_work_time"
+	ConsumableFilter    ConsumableKind = "filter_work_time"
+	ConsumableSensor    ConsumableKind = "sensor_dirty_time"
+	// ConsumableDustBag is the bag of an auto-empty dock, its usage is the number of dust
+	// collections.
+	ConsumableDustBag ConsumableKind = "dust_collection_work_times"
+	// ConsumableMop is the mop cleaning brush of a mop washing dock, its usage is the number
+	// of washes.
+	ConsumableMop ConsumableKind = "cleaning_brush_work_times"
+	// ConsumableStrainer is the strainer of a mop washing dock, its usage is the number of
+	// washes.
+	ConsumableStrainer ConsumableKind = "strainer_work_times"
+)
+
+// Counted reports whether the usage of the consumable is counted in uses rather than
+// measured in time.
+func (k ConsumableKind) Counted() bool {
+	_, ok := consumableLimits[k]
+	return ok
+}
+
+// consumableLifetimes are the recommended replacement intervals of each timed consumable, as
+// documented by Roborock.
+var consumableLifetimes = map[ConsumableKind]time.Duration{
+	ConsumableMainBrush: 300 * time.Hour,
+	ConsumableSideBrush: 200 * time.Hour,
+	ConsumableFilter:    150 * time.Hour,
+	ConsumableSensor:    30 * time.Hour,
+}
+
+// consumableLimits are the recommended number of uses after which each counted consumable
+// should be replaced or cleaned.
+var consumableLimits = map[ConsumableKind]int{
+	ConsumableDustBag:  60,
+	ConsumableMop:      300,
+	ConsumableStrainer: 150,
+}
+
+// ConsumableLifetimes returns a copy of the recommended replacement intervals of each
+// consumable.
+func ConsumableLifetimes() map[ConsumableKind]time.Duration {
+	ret := make(map[ConsumableKind]time.Duration, len(consumableLifetimes))
+	for k, d := range consumableLifetimes {
+		ret[k] = d
+	}
+
+	return ret
+}
+
+// ConsumableLimits returns a copy of the recommended number of uses of each counted
+// consumable.
+func ConsumableLimits() map[ConsumableKind]int {
+	ret := make(map[ConsumableKind]int, len(consumableLimits))
+	for k, n := range consumableLimits {
+		ret[k] = n
+	}
+
+	return ret
+}
+
+// Consumables holds the usage of each consumable since it was last reset, timed consumables
+// hold how long they have been in use and counted consumables how many times they have been
+// used. Consumables not reported by the vacuum are zero.
+type Consumables struct {
+	MainBrush time.Duration
+	SideBrush time.Duration
+	Filter    time.Duration
+	Sensor    time.Duration
+	DustBag   int
+	Mop       int
+	Strainer  int
+
+	// model provides the lifetimes and limits, DefaultModel is used if unset.
+	model *Model
+}
+
+func (c *Consumables) getModel() *Model {
+	if c.model != nil {
+		return c.model
+	}
+
+	return DefaultModel
+}
+
+// Used returns how long the timed consumable has been in use, it is zero for counted
+// consumables.
+func (c *Consumables) Used(kind ConsumableKind) time.Duration {
+	switch kind {
+	case ConsumableMainBrush:
+		return c.MainBrush
+	case ConsumableSideBrush:
+		return c.SideBrush
+	case ConsumableFilter:
+		return c.Filter
+	case ConsumableSensor:
+		return c.Sensor
+	}
+
+	return 0
+}
+
+// Remaining returns how long the timed consumable can be used before it should be replaced,
+// this is never negative.
+func (c *Consumables) Remaining(kind ConsumableKind) time.Duration {
+	remaining := c.getModel().ConsumableLifetimes[kind] - c.Used(kind)
+	if remaining < 0 {
+		return 0
+	}
+
+	return remaining
+}
+
+// UsedCount returns how many times the counted consumable has been used, it is zero for
+// timed consumables.
+func (c *Consumables) UsedCount(kind ConsumableKind) int {
+	switch kind {
+	case ConsumableDustBag:
+		return c.DustBag
+	case ConsumableMop:
+		return c.Mop
+	case ConsumableStrainer:
+		return c.Strainer
+	}
+
+	return 0
+}
+
+// RemainingCount returns how many more times the counted consumable can be used before it
+// should be replaced or cleaned, this is never negative.
+func (c *Consumables) RemainingCount(kind ConsumableKind) int {
+	remaining := c.getModel().ConsumableLimits[kind] - c.UsedCount(kind)
+	if remaining < 0 {
+		return 0
+	}
+
+	return remaining
+}
+
+// RemainingPercent returns the percentage (0-100) of the consumable's lifetime remaining.
+func (c *Consumables) RemainingPercent(kind ConsumableKind) int {
+	if kind.Counted() {
+		limit := c.getModel().ConsumableLimits[kind]
+		if limit == 0 {
+			return 0
+		}
+
+		return 100 * c.RemainingCount(kind) / limit
+	}
+
+	lifetime := c.getModel().ConsumableLifetimes[kind]
+	if lifetime == 0 {
+		return 0
+	}
+
+	return int(100 * c.Remaining(kind) / lifetime)
+}
+
+// GetConsumables retrieves the usage of the Vacuum's consumables. The remaining lifetimes are
+// calculated using the lifetimes and limits of the Vacuum's model, or the recommended ones if
+// the model cannot be retrieved.
+func (v *Vacuum) GetConsumables() (*Consumables, error) {
+	m := v.modelOrDefault()
+
+	rsp := make([]map[ConsumableKind]int64, 0)
+
+	err := v.do("get_consumable", nil, &rsp)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rsp) != 1 {
+		return nil, ErrUnexpectedResponse
+	}
+
+	// Work times are reported in seconds.
+	d := func(kind ConsumableKind) time.Duration {
+		return time.Duration(rsp[0][kind]) * time.Second
+	}
+
+	return &Consumables{
+		MainBrush: d(ConsumableMainBrush),
+		SideBrush: d(ConsumableSideBrush),
+		Filter:    d(ConsumableFilter),
+		Sensor:    d(ConsumableSensor),
+		DustBag:   int(rsp[0][ConsumableDustBag]),
+		Mop:       int(rsp[0][ConsumableMop]),
+		Strainer:  int(rsp[0][ConsumableStrainer]),
+		model:     m,
+	}, nil
+}
+
+// ResetConsumable resets the usage of a consumable, this should be called after it has
+// been replaced or cleaned.
+func (v *Vacuum) ResetConsumable(kind ConsumableKind) error {
+	if _, ok := consumableLifetimes[kind]; !ok && !kind.Counted() {
+		return fmt.Errorf("unknown consumable %q", kind)
+	}
+
+	p, err := json.Marshal([]ConsumableKind{kind})
+	if err != nil {
+		return err
+	}
+
+	return v.doParams("reset_consumable", p)
+}
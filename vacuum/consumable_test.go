@@ -0,0 +1,84 @@
+package vacuum
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVacuum_GetConsumables(t *testing.T) {
+	t.Parallel()
+
+	v := &Vacuum{
+		client: &mockClient{
+			rsp: []byte(`
+				{
+					"result": [{
+							"main_brush_work_time": 540000,
+							"side_brush_work_time": 720000,
+							"filter_work_time": 432000,
+							"sensor_dirty_time": 18000,
+							"dust_collection_work_times": 45,
+							"strainer_work_times": 15
+						}
+					],
+					"id": 1
+				}`,
+			),
+		},
+	}
+
+	got, err := v.GetConsumables()
+	require.NoError(t, err)
+	assert.Equal(t, 150*time.Hour, got.MainBrush)
+	assert.Equal(t, 200*time.Hour, got.SideBrush)
+	assert.Equal(t, 45, got.DustBag)
+	assert.Equal(t, 0, got.Mop)
+
+	assert.Equal(t, 50, got.RemainingPercent(ConsumableMainBrush))
+	assert.Equal(t, 0, got.RemainingPercent(ConsumableSideBrush))
+	assert.Equal(t, 30*time.Hour, got.Remaining(ConsumableFilter))
+	assert.Equal(t, 25*time.Hour, got.Remaining(ConsumableSensor))
+	assert.Equal(t, 15, got.RemainingCount(ConsumableDustBag))
+	assert.Equal(t, 25, got.RemainingPercent(ConsumableDustBag))
+	assert.Equal(t, 90, got.RemainingPercent(ConsumableStrainer))
+	assert.Equal(t, 100, got.RemainingPercent(ConsumableMop))
+	assert.Equal(t, time.Duration(0), got.Used(ConsumableDustBag))
+}
+
+func TestVacuum_ResetConsumable(t *testing.T) {
+	t.Parallel()
+
+	c := &mockClient{}
+	v := &Vacuum{client: c}
+
+	err := v.ResetConsumable(ConsumableFilter)
+	require.NoError(t, err)
+	assert.JSONEq(t, `["filter_work_time"]`, string(c.reqs[0].Params))
+
+	err = v.ResetConsumable(ConsumableStrainer)
+	require.NoError(t, err)
+	assert.JSONEq(t, `["strainer_work_times"]`, string(c.reqs[1].Params))
+
+	err = v.ResetConsumable("unknown")
+	require.Error(t, err)
+}
+
+func TestConsumableLifetimes(t *testing.T) {
+	t.Parallel()
+
+	got := ConsumableLifetimes()
+	got[ConsumableFilter] = time.Hour
+
+	assert.Equal(t, 150*time.Hour, ConsumableLifetimes()[ConsumableFilter])
+	assert.Equal(t, 150*time.Hour, DefaultModel.ConsumableLifetimes[ConsumableFilter])
+
+	limits := ConsumableLimits()
+	limits[ConsumableStrainer] = 1
+
+	assert.Equal(t, 150, ConsumableLimits()[ConsumableStrainer])
+	assert.True(t, ConsumableStrainer.Counted())
+	assert.False(t, ConsumableFilter.Counted())
+}
@@ -0,0 +1,219 @@
+package vacuum
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron is a five field cron expression (minute hour day-of-month month day-of-week), as used
+// by the vacuum's timers.
+type Cron struct {
+	expr string
+
+	minute     uint64
+	hour       uint64
+	dayOfMonth uint64
+	month      uint64
+	dayOfWeek  uint64
+
+	// Standard cron matches either the day of month or the day of week when both are
+	// restricted.
+	dayOfMonthAny bool
+	dayOfWeekAny  bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	// 7 is also accepted for Sunday.
+	{name: "day of week", min: 0, max: 7},
+}
+
+// ParseCron parses a five field cron expression. Each field supports *, single values,
+// ranges (1-5), lists (1,3,5) and steps (*/15, 0-30/10).
+func ParseCron(expr string) (Cron, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return Cron{}, fmt.Errorf("cron expression %q must have %d fields", expr, len(cronFields))
+	}
+
+	bits := make([]uint64, len(fields))
+
+	for i, f := range fields {
+		b, err := parseCronField(f, cronFields[i])
+		if err != nil {
+			return Cron{}, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+
+		bits[i] = b
+	}
+
+	// Treat 7 as Sunday.
+	if bits[4]&(1<<7) != 0 {
+		bits[4] = bits[4]&^(1<<7) | 1
+	}
+
+	return Cron{
+		expr:          strings.Join(fields, " "),
+		minute:        bits[0],
+		hour:          bits[1],
+		dayOfMonth:    bits[2],
+		month:         bits[3],
+		dayOfWeek:     bits[4],
+		dayOfMonthAny: fields[2] == "*",
+		dayOfWeekAny:  fields[4] == "*",
+	}, nil
+}
+
+// WeeklyCron returns a Cron which runs at hour:minute on each of the provided days, or
+// every day if no days are provided.
+func WeeklyCron(hour, minute int, days ...time.Weekday) (Cron, error) {
+	dow := "*"
+
+	if len(days) > 0 {
+		d := make([]string, len(days))
+		for i, day := range days {
+			d[i] = strconv.Itoa(int(day))
+		}
+
+		dow = strings.Join(d, ",")
+	}
+
+	return ParseCron(fmt.Sprintf("%d %d * * %s", minute, hour, dow))
+}
+
+func parseCronField(s string, f cronField) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(s, ",") {
+		rng, step := part, 1
+
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+
+			rng = part[:i]
+
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step in %s field %q", f.name, part)
+			}
+		}
+
+		lo, hi := f.min, f.max
+
+		if rng != "*" {
+			bounds := strings.SplitN(rng, "-", 2)
+
+			var err error
+
+			lo, err = strconv.Atoi(bounds[0])
+			if err != nil {
+				return 0, fmt.Errorf("invalid %s field %q", f.name, part)
+			}
+
+			hi = lo
+
+			if len(bounds) == 2 {
+				hi, err = strconv.Atoi(bounds[1])
+				if err != nil {
+					return 0, fmt.Errorf("invalid %s field %q", f.name, part)
+				}
+			} else if step > 1 {
+				// A single value with a step runs from that value to the maximum.
+				hi = f.max
+			}
+		}
+
+		if lo < f.min || hi > f.max || lo > hi {
+			return 0, fmt.Errorf("%s field %q must be between %d and %d", f.name, part, f.min, f.max)
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, nil
+}
+
+func (c Cron) String() string {
+	return c.expr
+}
+
+// IsZero reports whether c is the zero value rather than a parsed expression.
+func (c Cron) IsZero() bool {
+	return c.expr == ""
+}
+
+// Matches reports whether the cron runs at the minute of t, in t's location.
+func (c Cron) Matches(t time.Time) bool {
+	return c.minute&(1<<uint(t.Minute())) != 0 &&
+		c.hour&(1<<uint(t.Hour())) != 0 &&
+		c.month&(1<<uint(t.Month())) != 0 &&
+		c.matchesDay(t)
+}
+
+func (c Cron) matchesDay(t time.Time) bool {
+	dom := c.dayOfMonth&(1<<uint(t.Day())) != 0
+	dow := c.dayOfWeek&(1<<uint(t.Weekday())) != 0
+
+	switch {
+	case c.dayOfMonthAny && c.dayOfWeekAny:
+		return true
+	case c.dayOfMonthAny:
+		return dow
+	case c.dayOfWeekAny:
+		return dom
+	}
+
+	return dom || dow
+}
+
+// Next returns the next time after t the cron runs, in t's location. The zero time is
+// returned if the cron never runs, e.g. on the 31st of February.
+func (c Cron) Next(t time.Time) time.Time {
+	if c.IsZero() {
+		return time.Time{}
+	}
+
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+
+	// Give up after five years, long enough to find any valid day of the month.
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if !c.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
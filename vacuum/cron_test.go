@@ -0,0 +1,117 @@
+package vacuum
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+	} {
+		_, err := ParseCron(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestCron_Next(t *testing.T) {
+	t.Parallel()
+
+	// Wednesday
+	from := time.Date(2022, 6, 1, 10, 30, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		expr string
+		want time.Time
+	}{
+		"later today": {
+			expr: "0 18 * * *",
+			want: time.Date(2022, 6, 1, 18, 0, 0, 0, time.UTC),
+		},
+		"tomorrow": {
+			expr: "15 9 * * *",
+			want: time.Date(2022, 6, 2, 9, 15, 0, 0, time.UTC),
+		},
+		"weekday list": {
+			expr: "36 9 * * 0,1,2",
+			want: time.Date(2022, 6, 5, 9, 36, 0, 0, time.UTC),
+		},
+		"sunday as 7": {
+			expr: "0 8 * * 7",
+			want: time.Date(2022, 6, 5, 8, 0, 0, 0, time.UTC),
+		},
+		"step": {
+			expr: "*/20 * * * *",
+			want: time.Date(2022, 6, 1, 10, 40, 0, 0, time.UTC),
+		},
+		"day of month or week": {
+			expr: "0 0 15 * 5",
+			want: time.Date(2022, 6, 3, 0, 0, 0, 0, time.UTC),
+		},
+		"next year": {
+			expr: "0 0 1 1 *",
+			want: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"never": {
+			expr: "0 0 31 2 *",
+			want: time.Time{},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c, err := ParseCron(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, c.Next(from))
+			assert.Equal(t, tt.expr, c.String())
+
+			if !tt.want.IsZero() {
+				assert.True(t, c.Matches(tt.want))
+			}
+		})
+	}
+}
+
+func TestCron_Next_DST(t *testing.T) {
+	t.Parallel()
+
+	loc, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	c, err := WeeklyCron(9, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "0 9 * * *", c.String())
+
+	// Clocks go forward on the 27th of March 2022, the timer should still run at 09:00 local time.
+	got := c.Next(time.Date(2022, 3, 26, 10, 0, 0, 0, loc))
+	assert.Equal(t, time.Date(2022, 3, 27, 9, 0, 0, 0, loc), got)
+	assert.Equal(t, 7, got.UTC().Hour())
+}
+
+func TestWeeklyCron(t *testing.T) {
+	t.Parallel()
+
+	c, err := WeeklyCron(20, 32, time.Monday, time.Friday)
+	require.NoError(t, err)
+	assert.Equal(t, "32 20 * * 1,5", c.String())
+
+	_, err = WeeklyCron(24, 0)
+	assert.Error(t, err)
+}
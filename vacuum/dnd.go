@@ -0,0 +1,152 @@
+package vacuum
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// TimeOfDay is a time of day to the minute.
+type TimeOfDay struct {
+	Hour   int
+	Minute int
+}
+
+// TimeOfDayOf returns the time of day of t, in t's location.
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	return TimeOfDay{
+		Hour:   t.Hour(),
+		Minute: t.Minute(),
+	}
+}
+
+// Validate ensures the hour is between 0-23 and the minute between 0-59.
+func (t TimeOfDay) Validate() error {
+	if t.Hour < 0 || t.Hour > 23 {
+		return fmt.Errorf("hour %d must be between 0 and 23", t.Hour)
+	}
+
+	if t.Minute < 0 || t.Minute > 59 {
+		return fmt.Errorf("minute %d must be between 0 and 59", t.Minute)
+	}
+
+	return nil
+}
+
+func (t TimeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d", t.Hour, t.Minute)
+}
+
+// minutes returns the number of minutes since midnight.
+func (t TimeOfDay) minutes() int {
+	return t.Hour*60 + t.Minute
+}
+
+type DNDTimer struct {
+	Enabled bool
+	Start   TimeOfDay
+	End     TimeOfDay
+}
+
+// Active reports whether Do Not Disturb is active at the time of day of t, in t's location.
+// The window includes Start but not End and may span midnight, e.g. 22:00 to 08:00.
+func (d DNDTimer) Active(t time.Time) bool {
+	if !d.Enabled {
+		return false
+	}
+
+	now := TimeOfDayOf(t).minutes()
+	start := d.Start.minutes()
+	end := d.End.minutes()
+
+	if start <= end {
+		return now >= start && now < end
+	}
+
+	return now >= start || now < end
+}
+
+// GetDNDTimer retrieves the Do Not Disturb timers for the Vacuum.
+func (v *Vacuum) GetDNDTimer() ([]DNDTimer, error) {
+	type dndTimer struct {
+		Enabled     int `json:"enabled"`
+		StartHour   int `json:"start_hour"`
+		StartMinute int `json:"start_minute"`
+		EndHour     int `json:"end_hour"`
+		EndMinute   int `json:"end_minute"`
+	}
+
+	rsp := make([]dndTimer, 0)
+
+	err := v.do("get_dnd_timer", nil, &rsp)
+	if err != nil {
+		return nil, err
+	}
+
+	timers := make([]DNDTimer, len(rsp))
+
+	for i, d := range rsp {
+		timers[i] = DNDTimer{
+			Enabled: d.Enabled == 1,
+			Start:   TimeOfDay{Hour: d.StartHour, Minute: d.StartMinute},
+			End:     TimeOfDay{Hour: d.EndHour, Minute: d.EndMinute},
+		}
+	}
+
+	return timers, nil
+}
+
+type SetDNDTimerParams struct {
+	Start TimeOfDay
+	End   TimeOfDay
+}
+
+// SetDNDTimer sets and enables the Do Not Disturb timer for the Vacuum. The times are in the
+// Vacuum's timezone, see Timezone.
+func (v *Vacuum) SetDNDTimer(params SetDNDTimerParams) error {
+	if err := params.Start.Validate(); err != nil {
+		return fmt.Errorf("invalid start: %w", err)
+	}
+
+	if err := params.End.Validate(); err != nil {
+		return fmt.Errorf("invalid end: %w", err)
+	}
+
+	if params.Start == params.End {
+		return fmt.Errorf("start and end must be different")
+	}
+
+	p, err := json.Marshal([]int{params.Start.Hour, params.Start.Minute, params.End.Hour, params.End.Minute})
+	if err != nil {
+		return err
+	}
+
+	return v.doParams("set_dnd_timer", p)
+}
+
+// CloseDNDTimer disables the Do Not Disturb timer for the Vacuum.
+func (v *Vacuum) CloseDNDTimer() error {
+	return v.doSimple("close_dnd_timer")
+}
+
+// IsDNDActive reports whether Do Not Disturb is active on the Vacuum at t. t is converted to
+// the Vacuum's timezone before being compared.
+func (v *Vacuum) IsDNDActive(t time.Time) (bool, error) {
+	timers, err := v.GetDNDTimer()
+	if err != nil {
+		return false, err
+	}
+
+	loc, err := v.Timezone()
+	if err != nil {
+		return false, err
+	}
+
+	for _, d := range timers {
+		if d.Active(t.In(loc)) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
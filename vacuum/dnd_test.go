@@ -0,0 +1,126 @@
+package vacuum
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNDTimer_Active(t *testing.T) {
+	t.Parallel()
+
+	at := func(hour, minute int) time.Time {
+		return time.Date(2022, 6, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	overnight := DNDTimer{
+		Enabled: true,
+		Start:   TimeOfDay{Hour: 22},
+		End:     TimeOfDay{Hour: 8},
+	}
+
+	assert.True(t, overnight.Active(at(22, 0)))
+	assert.True(t, overnight.Active(at(23, 59)))
+	assert.True(t, overnight.Active(at(0, 0)))
+	assert.True(t, overnight.Active(at(7, 59)))
+	assert.False(t, overnight.Active(at(8, 0)))
+	assert.False(t, overnight.Active(at(21, 59)))
+
+	daytime := DNDTimer{
+		Enabled: true,
+		Start:   TimeOfDay{Hour: 12, Minute: 30},
+		End:     TimeOfDay{Hour: 14},
+	}
+
+	assert.True(t, daytime.Active(at(12, 30)))
+	assert.False(t, daytime.Active(at(14, 0)))
+	assert.False(t, daytime.Active(at(12, 29)))
+
+	overnight.Enabled = false
+	assert.False(t, overnight.Active(at(23, 0)))
+}
+
+func TestVacuum_GetDNDTimer(t *testing.T) {
+	t.Parallel()
+
+	v := &Vacuum{
+		client: &mockClient{
+			rsp: []byte(`
+				{
+					"result": [{
+							"start_hour": 22,
+							"start_minute": 0,
+							"end_hour": 8,
+							"end_minute": 30,
+							"enabled": 1
+						}
+					],
+					"id": 1
+				}`,
+			),
+		},
+	}
+
+	got, err := v.GetDNDTimer()
+	require.NoError(t, err)
+	assert.Equal(t, []DNDTimer{{
+		Enabled: true,
+		Start:   TimeOfDay{Hour: 22},
+		End:     TimeOfDay{Hour: 8, Minute: 30},
+	}}, got)
+	assert.Equal(t, "08:30", got[0].End.String())
+}
+
+func TestVacuum_SetDNDTimer(t *testing.T) {
+	t.Parallel()
+
+	c := &mockClient{}
+	v := &Vacuum{client: c}
+
+	err := v.SetDNDTimer(SetDNDTimerParams{
+		Start: TimeOfDay{Hour: 22},
+		End:   TimeOfDay{Hour: 8, Minute: 30},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `[22, 0, 8, 30]`, string(c.reqs[0].Params))
+
+	for _, params := range []SetDNDTimerParams{
+		{Start: TimeOfDay{Hour: 24}, End: TimeOfDay{Hour: 8}},
+		{Start: TimeOfDay{Hour: 22}, End: TimeOfDay{Hour: 8, Minute: 60}},
+		{Start: TimeOfDay{Hour: -1}, End: TimeOfDay{Hour: 8}},
+		{Start: TimeOfDay{Hour: 8}, End: TimeOfDay{Hour: 8}},
+	} {
+		err = v.SetDNDTimer(params)
+		assert.Error(t, err)
+	}
+
+	assert.Len(t, c.reqs, 1)
+}
+
+func TestVacuum_IsDNDActive(t *testing.T) {
+	t.Parallel()
+
+	c := &mockClient{
+		rsps: map[string][][]byte{
+			"get_timezone": {testTimezone},
+			"get_dnd_timer": {[]byte(`
+				{
+					"result": [{"start_hour": 22, "start_minute": 0, "end_hour": 8, "end_minute": 0, "enabled": 1}],
+					"id": 1
+				}`,
+			)},
+		},
+	}
+	v := &Vacuum{client: c}
+
+	// 21:30 UTC is 23:30 in Berlin during the summer.
+	got, err := v.IsDNDActive(time.Date(2022, 6, 1, 21, 30, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.True(t, got)
+
+	got, err = v.IsDNDActive(time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.False(t, got)
+}
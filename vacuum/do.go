@@ -2,8 +2,12 @@ package vacuum
 
 import (
 	"encoding/json"
+	"fmt"
 )
 
+// codeMethodNotFound is the error code returned by the vacuum when it does not recognise a method.
+const codeMethodNotFound = -32601
+
 type request struct {
 	ID     int64           `json:"id"`
 	Method string          `json:"method"`
@@ -16,12 +20,33 @@ type rawResponse struct {
 	Code      int             `json:"code"`
 	Message   string          `json:"message"`
 	Result    json.RawMessage `json:"result"`
+	Error     *DeviceError    `json:"error"`
+}
+
+// DeviceError is returned when the vacuum responds to a request with an error.
+type DeviceError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *DeviceError) Error() string {
+	return fmt.Sprintf("device error %d: %s", e.Code, e.Message)
+}
+
+// Is allows a DeviceError for an unknown method to be matched against ErrNotSupported.
+func (e *DeviceError) Is(target error) bool {
+	return target == ErrNotSupported && e.Code == codeMethodNotFound
 }
 
 func (v *Vacuum) doSimple(method string) error {
+	return v.doParams(method, nil)
+}
+
+// doParams sends the method with the provided params and expects an "ok" response.
+func (v *Vacuum) doParams(method string, params json.RawMessage) error {
 	m := make([]string, 0)
 
-	err := v.do(method, nil, &m)
+	err := v.do(method, params, &m)
 	if err != nil {
 		return err
 	}
@@ -34,6 +59,10 @@ func (v *Vacuum) doSimple(method string) error {
 }
 
 func (v *Vacuum) do(method string, params json.RawMessage, rsp interface{}) error {
+	// Requests may be sent from multiple goroutines, e.g. while installing a sound pack.
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
 	v.id++
 
 	req := &request{
@@ -59,7 +88,9 @@ func (v *Vacuum) do(method string, params json.RawMessage, rsp interface{}) erro
 		return err
 	}
 
-	// TODO: Check rawResponse
+	if rr.Error != nil {
+		return rr.Error
+	}
 
 	if rr.Result != nil && rsp != nil {
 		err = json.Unmarshal(rr.Result, rsp)
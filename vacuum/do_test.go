@@ -1,8 +1,10 @@
 package vacuum
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -23,3 +25,25 @@ func TestVacuum_DoSimple(t *testing.T) {
 	err := v.doSimple("test")
 	require.NoError(t, err)
 }
+
+func TestVacuum_DeviceError(t *testing.T) {
+	t.Parallel()
+
+	v := &Vacuum{
+		client: &mockClient{
+			rsp: []byte(`
+				{
+					"error": {"code": -32601, "message": "Method not found."},
+					"id": 1
+				}`,
+			),
+		},
+	}
+
+	err := v.doSimple("test")
+	require.True(t, errors.Is(err, ErrNotSupported))
+
+	var devErr *DeviceError
+	require.True(t, errors.As(err, &devErr))
+	assert.Equal(t, "Method not found.", devErr.Message)
+}
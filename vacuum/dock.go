@@ -0,0 +1,375 @@
+package vacuum
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type DockType int
+
+const (
+	// DockTypeCharging is a dock which only charges the vacuum.
+	DockTypeCharging DockType = 0
+	// DockTypeAutoEmpty empties the dust bin of the vacuum.
+	DockTypeAutoEmpty DockType = 1
+	// DockTypeEmptyWashFill empties the dust bin, washes the mop and refills the water tank.
+	DockTypeEmptyWashFill DockType = 3
+	// DockTypeAutoEmptyPure empties the dust bin of the vacuum.
+	DockTypeAutoEmptyPure DockType = 5
+	// DockTypeS7MaxVUltra empties the dust bin, washes and dries the mop and refills the water
+	// tank.
+	DockTypeS7MaxVUltra DockType = 6
+	// DockTypeS8Ultra empties the dust bin, washes and dries the mop and refills the water tank.
+	DockTypeS8Ultra DockType = 7
+	// DockTypeS8MaxVUltra empties the dust bin, washes and dries the mop and refills the water
+	// tank.
+	DockTypeS8MaxVUltra DockType = 8
+)
+
+func (d DockType) String() string {
+	switch d {
+	case DockTypeCharging:
+		return "Charging"
+	case DockTypeAutoEmpty:
+		return "AutoEmpty"
+	case DockTypeEmptyWashFill:
+		return "EmptyWashFill"
+	case DockTypeAutoEmptyPure:
+		return "AutoEmptyPure"
+	case DockTypeS7MaxVUltra:
+		return "S7MaxVUltra"
+	case DockTypeS8Ultra:
+		return "S8Ultra"
+	case DockTypeS8MaxVUltra:
+		return "S8MaxVUltra"
+	}
+
+	return fmt.Sprintf("DockType(%d)", int(d))
+}
+
+// known reports whether the dock type is one of the known types.
+func (d DockType) known() bool {
+	switch d {
+	case DockTypeCharging, DockTypeAutoEmpty, DockTypeEmptyWashFill, DockTypeAutoEmptyPure,
+		DockTypeS7MaxVUltra, DockTypeS8Ultra, DockTypeS8MaxVUltra:
+		return true
+	}
+
+	return false
+}
+
+// CanCollectDust reports whether the dock can empty the dust bin of the vacuum. Unknown docks
+// are assumed to be capable, leaving the vacuum to reject the request.
+func (d DockType) CanCollectDust() bool {
+	return d != DockTypeCharging
+}
+
+// CanWash reports whether the dock can wash and dry the mop. Unknown docks are assumed to be
+// capable, leaving the vacuum to reject the request.
+func (d DockType) CanWash() bool {
+	switch d {
+	case DockTypeEmptyWashFill, DockTypeS7MaxVUltra, DockTypeS8Ultra, DockTypeS8MaxVUltra:
+		return true
+	}
+
+	return !d.known()
+}
+
+type DockStatus struct {
+	Type  DockType
+	Error DockErrorCode
+}
+
+// DockStatus retrieves the type of dock the vacuum is paired with and any error it reports.
+func (v *Vacuum) DockStatus() (*DockStatus, error) {
+	s, err := v.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	return &DockStatus{
+		Type:  s.DockType,
+		Error: s.DockErrorStatus,
+	}, nil
+}
+
+// requireDock returns ErrNotSupported if the Vacuum's model does not support the method or
+// cannot be paired with a dock supporting the feature, or its dock does not support the
+// feature. The model is not consulted if it cannot be retrieved.
+func (v *Vacuum) requireDock(method string, supported func(DockType) bool) error {
+	m, err := v.Model()
+	if err == nil {
+		if !m.Supports(method) {
+			return fmt.Errorf("%w: %s on %s", ErrNotSupported, method, m.Name)
+		}
+
+		if !anyDockType(m.DockTypes, supported) {
+			return fmt.Errorf("%w: %s on %s, no compatible dock", ErrNotSupported, method, m.Name)
+		}
+	}
+
+	s, err := v.DockStatus()
+	if err != nil {
+		return err
+	}
+
+	if !supported(s.Type) {
+		return fmt.Errorf("%w: not supported by %s dock", ErrNotSupported, s.Type)
+	}
+
+	return nil
+}
+
+// anyDockType reports whether any of the dock types support the feature.
+func anyDockType(types []DockType, supported func(DockType) bool) bool {
+	for _, t := range types {
+		if supported(t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StartCollectDust empties the dust bin of the vacuum into the dock.
+func (v *Vacuum) StartCollectDust() error {
+	err := v.requireDock("app_start_collect_dust", DockType.CanCollectDust)
+	if err != nil {
+		return err
+	}
+
+	return v.doSimple("app_start_collect_dust")
+}
+
+// StopCollectDust stops emptying the dust bin.
+func (v *Vacuum) StopCollectDust() error {
+	err := v.requireMethod("app_stop_collect_dust")
+	if err != nil {
+		return err
+	}
+
+	return v.doSimple("app_stop_collect_dust")
+}
+
+// DustCollectionMode controls how often, and how long, the dock empties the dust bin.
+type DustCollectionMode int
+
+const (
+	DustCollectionModeSmart DustCollectionMode = iota
+	DustCollectionModeQuick
+	DustCollectionModeDaily
+	DustCollectionModeStrong
+	DustCollectionModeMax
+)
+
+func (d DustCollectionMode) String() string {
+	switch d {
+	case DustCollectionModeSmart:
+		return "Smart"
+	case DustCollectionModeQuick:
+		return "Quick"
+	case DustCollectionModeDaily:
+		return "Daily"
+	case DustCollectionModeStrong:
+		return "Strong"
+	case DustCollectionModeMax:
+		return "Max"
+	}
+
+	return fmt.Sprintf("DustCollectionMode(%d)", int(d))
+}
+
+type dustCollectionMode struct {
+	Mode DustCollectionMode `json:"mode"`
+}
+
+// GetDustCollectionMode retrieves how the dock empties the dust bin.
+func (v *Vacuum) GetDustCollectionMode() (DustCollectionMode, error) {
+	err := v.requireMethod("get_dust_collection_mode")
+	if err != nil {
+		return 0, err
+	}
+
+	rsp := make([]dustCollectionMode, 0)
+
+	err = v.do("get_dust_collection_mode", nil, &rsp)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(rsp) != 1 {
+		return 0, ErrUnexpectedResponse
+	}
+
+	return rsp[0].Mode, nil
+}
+
+// SetDustCollectionMode sets how the dock empties the dust bin.
+func (v *Vacuum) SetDustCollectionMode(mode DustCollectionMode) error {
+	if mode < DustCollectionModeSmart || mode > DustCollectionModeMax {
+		return fmt.Errorf("unknown dust collection mode %s", mode)
+	}
+
+	err := v.requireDock("set_dust_collection_mode", DockType.CanCollectDust)
+	if err != nil {
+		return err
+	}
+
+	p, err := json.Marshal([]dustCollectionMode{{Mode: mode}})
+	if err != nil {
+		return err
+	}
+
+	return v.doParams("set_dust_collection_mode", p)
+}
+
+// StartWash washes the mop in the dock.
+func (v *Vacuum) StartWash() error {
+	err := v.requireDock("app_start_wash", DockType.CanWash)
+	if err != nil {
+		return err
+	}
+
+	return v.doSimple("app_start_wash")
+}
+
+// StopWash stops washing the mop.
+func (v *Vacuum) StopWash() error {
+	err := v.requireMethod("app_stop_wash")
+	if err != nil {
+		return err
+	}
+
+	return v.doSimple("app_stop_wash")
+}
+
+// WashTowelMode controls how thoroughly the dock washes the mop.
+type WashTowelMode int
+
+const (
+	WashTowelModeLight WashTowelMode = iota
+	WashTowelModeBalanced
+	WashTowelModeDeep
+)
+
+func (w WashTowelMode) String() string {
+	switch w {
+	case WashTowelModeLight:
+		return "Light"
+	case WashTowelModeBalanced:
+		return "Balanced"
+	case WashTowelModeDeep:
+		return "Deep"
+	}
+
+	return fmt.Sprintf("WashTowelMode(%d)", int(w))
+}
+
+type washTowelMode struct {
+	Mode WashTowelMode `json:"wash_mode"`
+}
+
+// GetWashTowelMode retrieves how thoroughly the dock washes the mop.
+func (v *Vacuum) GetWashTowelMode() (WashTowelMode, error) {
+	err := v.requireMethod("get_wash_towel_mode")
+	if err != nil {
+		return 0, err
+	}
+
+	rsp := make([]washTowelMode, 0)
+
+	err = v.do("get_wash_towel_mode", nil, &rsp)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(rsp) != 1 {
+		return 0, ErrUnexpectedResponse
+	}
+
+	return rsp[0].Mode, nil
+}
+
+// SetWashTowelMode sets how thoroughly the dock washes the mop.
+func (v *Vacuum) SetWashTowelMode(mode WashTowelMode) error {
+	if mode < WashTowelModeLight || mode > WashTowelModeDeep {
+		return fmt.Errorf("unknown wash towel mode %s", mode)
+	}
+
+	err := v.requireDock("set_wash_towel_mode", DockType.CanWash)
+	if err != nil {
+		return err
+	}
+
+	p, err := json.Marshal([]washTowelMode{{Mode: mode}})
+	if err != nil {
+		return err
+	}
+
+	return v.doParams("set_wash_towel_mode", p)
+}
+
+// DryerSetting controls drying of the mop after it has been washed.
+type DryerSetting struct {
+	Enabled bool
+	// DryTime is how long the mop is dried for, it is sent to the vacuum in whole seconds.
+	DryTime time.Duration
+}
+
+type dryerSetting struct {
+	Status int `json:"status"`
+	On     struct {
+		DryTime int64 `json:"dry_time"`
+	} `json:"on"`
+}
+
+// GetDryerSetting retrieves the mop drying settings.
+func (v *Vacuum) GetDryerSetting() (*DryerSetting, error) {
+	err := v.requireMethod("get_dryer_setting")
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := make([]dryerSetting, 0)
+
+	err = v.do("get_dryer_setting", nil, &rsp)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rsp) != 1 {
+		return nil, ErrUnexpectedResponse
+	}
+
+	return &DryerSetting{
+		Enabled: rsp[0].Status == 1,
+		DryTime: time.Duration(rsp[0].On.DryTime) * time.Second,
+	}, nil
+}
+
+// SetDryerSetting sets the mop drying settings.
+func (v *Vacuum) SetDryerSetting(setting DryerSetting) error {
+	if setting.Enabled && setting.DryTime < time.Second {
+		return errors.New("dry time must be at least one second")
+	}
+
+	err := v.requireDock("app_set_dryer_setting", DockType.CanWash)
+	if err != nil {
+		return err
+	}
+
+	d := dryerSetting{}
+	if setting.Enabled {
+		d.Status = 1
+	}
+	d.On.DryTime = int64(setting.DryTime / time.Second)
+
+	p, err := json.Marshal([]dryerSetting{d})
+	if err != nil {
+		return err
+	}
+
+	return v.doParams("app_set_dryer_setting", p)
+}
@@ -0,0 +1,184 @@
+package vacuum
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDockStatus(dockType DockType) []byte {
+	return []byte(fmt.Sprintf(`{"result": [{"state": 8, "dock_type": %d, "dock_error_status": 0}], "id": 1}`, dockType))
+}
+
+func TestVacuum_DockStatus(t *testing.T) {
+	t.Parallel()
+
+	v := &Vacuum{
+		client: &mockClient{
+			rsp: []byte(`{"result": [{"state": 8, "dock_type": 3, "dock_error_status": 39}], "id": 1}`),
+		},
+	}
+
+	got, err := v.DockStatus()
+	require.NoError(t, err)
+	assert.Equal(t, &DockStatus{Type: DockTypeEmptyWashFill, Error: DockErrorCodeWasteWaterTankFull}, got)
+}
+
+func TestVacuum_StartCollectDust(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		dockType DockType
+		methods  []string
+		wantErr  error
+	}{
+		"AutoEmpty": {
+			dockType: DockTypeAutoEmpty,
+			methods:  []string{"get_status", "app_start_collect_dust"},
+		},
+		"S8Ultra": {
+			dockType: DockTypeS8Ultra,
+			methods:  []string{"get_status", "app_start_collect_dust"},
+		},
+		"Unknown": {
+			dockType: DockType(9),
+			methods:  []string{"get_status", "app_start_collect_dust"},
+		},
+		"Charging": {
+			dockType: DockTypeCharging,
+			methods:  []string{"get_status"},
+			wantErr:  ErrNotSupported,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &mockClient{
+				rsps: map[string][][]byte{
+					"get_status": {testDockStatus(tt.dockType)},
+				},
+			}
+			v := &Vacuum{client: c, modelName: "roborock.vacuum.a27"}
+
+			err := v.StartCollectDust()
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.methods, c.methods())
+		})
+	}
+}
+
+func TestVacuum_StartWash(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		dockType DockType
+		methods  []string
+		wantErr  error
+	}{
+		"S8Ultra": {
+			dockType: DockTypeS8Ultra,
+			methods:  []string{"get_status", "app_start_wash"},
+		},
+		"S8MaxVUltra": {
+			dockType: DockTypeS8MaxVUltra,
+			methods:  []string{"get_status", "app_start_wash"},
+		},
+		"AutoEmptyPure": {
+			dockType: DockTypeAutoEmptyPure,
+			methods:  []string{"get_status"},
+			wantErr:  ErrNotSupported,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &mockClient{
+				rsps: map[string][][]byte{
+					"get_status": {testDockStatus(tt.dockType)},
+				},
+			}
+			v := &Vacuum{client: c, modelName: "roborock.vacuum.a27"}
+
+			err := v.StartWash()
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.methods, c.methods())
+		})
+	}
+}
+
+func TestVacuum_DustCollectionMode(t *testing.T) {
+	t.Parallel()
+
+	c := &mockClient{
+		rsps: map[string][][]byte{
+			"get_status":               {testDockStatus(DockTypeAutoEmpty)},
+			"get_dust_collection_mode": {[]byte(`{"result": [{"mode": 2}], "id": 1}`)},
+		},
+	}
+	v := &Vacuum{client: c, modelName: "roborock.vacuum.a27"}
+
+	got, err := v.GetDustCollectionMode()
+	require.NoError(t, err)
+	assert.Equal(t, DustCollectionModeDaily, got)
+
+	require.NoError(t, v.SetDustCollectionMode(DustCollectionModeMax))
+	assert.JSONEq(t, `[{"mode": 4}]`, string(c.reqs[2].Params))
+
+	assert.Error(t, v.SetDustCollectionMode(DustCollectionMode(7)))
+}
+
+func TestVacuum_WashTowelMode(t *testing.T) {
+	t.Parallel()
+
+	c := &mockClient{
+		rsps: map[string][][]byte{
+			"get_status":          {testDockStatus(DockTypeEmptyWashFill)},
+			"get_wash_towel_mode": {[]byte(`{"result": [{"wash_mode": 1}], "id": 1}`)},
+		},
+	}
+	v := &Vacuum{client: c, modelName: "roborock.vacuum.a27"}
+
+	got, err := v.GetWashTowelMode()
+	require.NoError(t, err)
+	assert.Equal(t, WashTowelModeBalanced, got)
+
+	require.NoError(t, v.SetWashTowelMode(WashTowelModeDeep))
+	assert.JSONEq(t, `[{"wash_mode": 2}]`, string(c.reqs[2].Params))
+}
+
+func TestVacuum_DryerSetting(t *testing.T) {
+	t.Parallel()
+
+	c := &mockClient{
+		rsps: map[string][][]byte{
+			"get_status":        {testDockStatus(DockTypeS8MaxVUltra)},
+			"get_dryer_setting": {[]byte(`{"result": [{"status": 1, "on": {"dry_time": 10800}}], "id": 1}`)},
+		},
+	}
+	v := &Vacuum{client: c, modelName: "roborock.vacuum.a27"}
+
+	got, err := v.GetDryerSetting()
+	require.NoError(t, err)
+	assert.Equal(t, &DryerSetting{Enabled: true, DryTime: 3 * time.Hour}, got)
+
+	require.NoError(t, v.SetDryerSetting(DryerSetting{Enabled: true, DryTime: 2 * time.Hour}))
+	assert.JSONEq(t, `[{"status": 1, "on": {"dry_time": 7200}}]`, string(c.reqs[2].Params))
+}
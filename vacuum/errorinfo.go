@@ -0,0 +1,109 @@
+package vacuum
+
+// ErrorInfo describes an error reported by the vacuum or its dock.
+type ErrorInfo struct {
+	Description string
+	// Remedy suggests how the user can resolve the error, it is empty if there is nothing to do.
+	Remedy string
+}
+
+// ErrorCatalog translates error codes into ErrorInfo. Catalogs for other languages can be
+// created with Fallback set to EnglishErrorCatalog, so untranslated codes are still described.
+type ErrorCatalog struct {
+	Errors     map[ErrorCode]ErrorInfo
+	DockErrors map[DockErrorCode]ErrorInfo
+	// Fallback is consulted for codes not in this catalog.
+	Fallback *ErrorCatalog
+}
+
+// ErrorInfo returns the ErrorInfo of the code. Unknown codes are described by their String.
+func (c *ErrorCatalog) ErrorInfo(code ErrorCode) ErrorInfo {
+	for cat := c; cat != nil; cat = cat.Fallback {
+		if info, ok := cat.Errors[code]; ok {
+			return info
+		}
+	}
+
+	return ErrorInfo{Description: code.String()}
+}
+
+// DockErrorInfo returns the ErrorInfo of the dock error code. Unknown codes are described by
+// their String.
+func (c *ErrorCatalog) DockErrorInfo(code DockErrorCode) ErrorInfo {
+	for cat := c; cat != nil; cat = cat.Fallback {
+		if info, ok := cat.DockErrors[code]; ok {
+			return info
+		}
+	}
+
+	return ErrorInfo{Description: code.String()}
+}
+
+// DefaultErrorCatalog is used by the Description and Remedy methods of ErrorCode and
+// DockErrorCode. It may be replaced with a translated catalog before the package is used.
+var DefaultErrorCatalog = EnglishErrorCatalog
+
+// EnglishErrorCatalog describes the known error codes in English.
+var EnglishErrorCatalog = &ErrorCatalog{
+	Errors: map[ErrorCode]ErrorInfo{
+		ErrorCodeNoError:                    {"No error", ""},
+		ErrorCodeLaserSensorFault:           {"Laser distance sensor error", "Remove any obstructions from around the laser distance sensor and check it can rotate freely"},
+		ErrorCodeCollisionSensorFault:       {"Collision sensor error", "Tap the bumper to free it and remove any debris"},
+		ErrorCodeWheelFloating:              {"Wheels suspended", "Move the robot to a flat surface and restart"},
+		ErrorCodeCliffSensorFault:           {"Cliff sensor error", "Clean the cliff sensors and move the robot away from any drops"},
+		ErrorCodeMainBrushBlocked:           {"Main brush jammed", "Clean main brush and its bearings"},
+		ErrorCodeSideBrushBlocked:           {"Side brush jammed", "Remove and clean the side brush"},
+		ErrorCodeWheelBlocked:               {"Wheels jammed", "Move the robot and clean the wheels"},
+		ErrorCodeDeviceStuck:                {"Robot trapped", "Clear obstacles from around the robot"},
+		ErrorCodeDustBinMissing:             {"Dustbin missing", "Install the dustbin and filter"},
+		ErrorCodeFilterBlocked:              {"Filter blocked", "Dry or replace the filter"},
+		ErrorCodeMagneticFieldDetected:      {"Strong magnetic field detected", "Move robot away from magnetic strip"},
+		ErrorCodeLowBattery:                 {"Low battery", "Recharge the robot"},
+		ErrorCodeChargingProblem:            {"Charging error", "Clean the charging contacts on the robot and dock"},
+		ErrorCodeBatteryFailure:             {"Battery error", "Move the robot somewhere within its operating temperature"},
+		ErrorCodeWallSensorFault:            {"Wall sensor error", "Clean the wall sensor"},
+		ErrorCodeUnevenSurface:              {"Uneven surface", "Move the robot to a flat surface and restart"},
+		ErrorCodeSideBrushFailure:           {"Side brush error", "Reset the robot"},
+		ErrorCodeSuctionFanFailure:          {"Suction fan error", "Reset the robot"},
+		ErrorCodeUnpoweredChargingStation:   {"Dock not powered", "Check the dock is plugged in"},
+		ErrorCodeLaserPressureSensorProblem: {"Laser distance sensor blocked", "Check the laser distance sensor cover is not pressed"},
+		ErrorCodeChargeSensorProblem:        {"Charging sensor dirty", "Clean the charging contacts"},
+		ErrorCodeDockProblem:                {"Dock signal emitter dirty", "Clean the signal emitter of the dock"},
+		ErrorCodeInvisibleWallDetected:      {"No-go zone or invisible wall detected", "Move the robot away from the no-go zone"},
+		ErrorCodeWallSensorDirty:            {"Wall sensor dirty", "Clean the wall sensor"},
+		ErrorCodeVibraRiseJammed:            {"VibraRise system jammed", "Check the mop mount for obstructions"},
+		ErrorCodeRobotOnCarpet:              {"Robot on carpet", "Move the robot to a hard floor and restart"},
+		ErrorCodeBinFull:                    {"Dustbin full", "Empty the dustbin"},
+		ErrorCodeInternalError:              {"Internal error", "Reset the robot"},
+	},
+	DockErrors: map[DockErrorCode]ErrorInfo{
+		DockErrorCodeOK:                        {"No error", ""},
+		DockErrorCodeDuctBlockage:              {"Dock duct blocked", "Clear the dust duct of the dock"},
+		DockErrorCodeWaterEmpty:                {"Clean water tank empty", "Refill the clean water tank"},
+		DockErrorCodeWasteWaterTankFull:        {"Waste water tank full", "Empty the waste water tank"},
+		DockErrorCodeMaintenanceBrushJammed:    {"Maintenance brush jammed", "Clean the brush of the washing tray"},
+		DockErrorCodeDirtyTankLatchOpen:        {"Waste water tank latch open", "Close the waste water tank latch"},
+		DockErrorCodeNoDustbin:                 {"Dust bag missing", "Install a dust bag in the dock"},
+		DockErrorCodeCleaningTankFullOrBlocked: {"Washing tray full or blocked", "Clean the washing tray"},
+	},
+}
+
+// Description describes the error using DefaultErrorCatalog.
+func (c ErrorCode) Description() string {
+	return DefaultErrorCatalog.ErrorInfo(c).Description
+}
+
+// Remedy suggests how to resolve the error using DefaultErrorCatalog.
+func (c ErrorCode) Remedy() string {
+	return DefaultErrorCatalog.ErrorInfo(c).Remedy
+}
+
+// Description describes the dock error using DefaultErrorCatalog.
+func (c DockErrorCode) Description() string {
+	return DefaultErrorCatalog.DockErrorInfo(c).Description
+}
+
+// Remedy suggests how to resolve the dock error using DefaultErrorCatalog.
+func (c DockErrorCode) Remedy() string {
+	return DefaultErrorCatalog.DockErrorInfo(c).Remedy
+}
@@ -0,0 +1,47 @@
+package vacuum
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnglishErrorCatalog(t *testing.T) {
+	t.Parallel()
+
+	for code := range errorCodeNames {
+		if code == ErrorCodeUnknown {
+			continue
+		}
+
+		assert.Contains(t, EnglishErrorCatalog.Errors, code, "missing description for %s", code)
+	}
+
+	for code := range dockErrorCodeNames {
+		assert.Contains(t, EnglishErrorCatalog.DockErrors, code, "missing description for %s", code)
+	}
+}
+
+func TestErrorCatalog_ErrorInfo(t *testing.T) {
+	t.Parallel()
+
+	german := &ErrorCatalog{
+		Errors: map[ErrorCode]ErrorInfo{
+			ErrorCodeMainBrushBlocked: {"Hauptbürste blockiert", "Hauptbürste reinigen"},
+		},
+		Fallback: EnglishErrorCatalog,
+	}
+
+	assert.Equal(t, ErrorInfo{"Hauptbürste blockiert", "Hauptbürste reinigen"}, german.ErrorInfo(ErrorCodeMainBrushBlocked))
+	assert.Equal(t, "Empty the dustbin", german.ErrorInfo(ErrorCodeBinFull).Remedy)
+	assert.Equal(t, "Refill the clean water tank", german.DockErrorInfo(DockErrorCodeWaterEmpty).Remedy)
+	assert.Equal(t, ErrorInfo{Description: "ErrorCode(99)"}, german.ErrorInfo(ErrorCode(99)))
+}
+
+func TestErrorCode_Description(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "Strong magnetic field detected", ErrorCodeMagneticFieldDetected.Description())
+	assert.Equal(t, "Move robot away from magnetic strip", ErrorCodeMagneticFieldDetected.Remedy())
+	assert.Equal(t, "Waste water tank full", DockErrorCodeWasteWaterTankFull.Description())
+}
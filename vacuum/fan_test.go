@@ -0,0 +1,173 @@
+package vacuum
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVacuum_SetFanSpeed(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		model      string
+		firmware   string
+		speed      FanSpeed
+		wantParams string
+		wantErr    error
+	}{
+		"v1 percentage": {
+			model:      "rockrobo.vacuum.v1",
+			firmware:   "3.3.9_003194",
+			speed:      FanSpeedTurbo,
+			wantParams: `[77]`,
+		},
+		"v1 3.5.7 preset": {
+			model:      "rockrobo.vacuum.v1",
+			firmware:   "3.5.7_002008",
+			speed:      FanSpeedTurbo,
+			wantParams: `[103]`,
+		},
+		"v1 3.5.8 percentage": {
+			model:      "rockrobo.vacuum.v1",
+			firmware:   "3.5.8_002034",
+			speed:      FanSpeedMax,
+			wantParams: `[100]`,
+		},
+		"s5 gentle": {
+			model:      "roborock.vacuum.s5",
+			speed:      FanSpeedGentle,
+			wantParams: `[105]`,
+		},
+		"default preset": {
+			model:      "roborock.vacuum.s6",
+			speed:      FanSpeedTurbo,
+			wantParams: `[103]`,
+		},
+		"s5 preset": {
+			model:      "roborock.vacuum.s5",
+			speed:      FanSpeedTurbo,
+			wantParams: `[103]`,
+		},
+		"s7 maxv max plus": {
+			model:      "roborock.vacuum.a27",
+			speed:      FanSpeedMaxPlus,
+			wantParams: `[108]`,
+		},
+		"unsupported": {
+			model:    "rockrobo.vacuum.v1",
+			firmware: "3.3.9_003194",
+			speed:    FanSpeedOff,
+			wantErr:  ErrNotSupported,
+		},
+		"s7 max plus unsupported": {
+			model:   "roborock.vacuum.a15",
+			speed:   FanSpeedMaxPlus,
+			wantErr: ErrNotSupported,
+		},
+		"s5 off unsupported": {
+			model:   "roborock.vacuum.s5",
+			speed:   FanSpeedOff,
+			wantErr: ErrNotSupported,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &mockClient{}
+			v := &Vacuum{client: c, modelName: tt.model, firmwareVersion: tt.firmware}
+
+			err := v.SetFanSpeed(tt.speed)
+			if tt.wantErr != nil {
+				require.True(t, errors.Is(err, tt.wantErr))
+				assert.Empty(t, c.reqs)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, []string{"set_custom_mode"}, c.methods())
+			assert.JSONEq(t, tt.wantParams, string(c.reqs[0].Params))
+		})
+	}
+}
+
+func TestVacuum_GetFanSpeed(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		rsp  []byte
+		want FanSpeed
+	}{
+		"preset": {
+			rsp:  []byte(`{"result": [104], "id": 1}`),
+			want: FanSpeedMax,
+		},
+		"auto": {
+			rsp:  []byte(`{"result": [106], "id": 1}`),
+			want: FanSpeedAuto,
+		},
+		"unknown power": {
+			rsp:  []byte(`{"result": [55], "id": 1}`),
+			want: FanSpeedCustom,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &mockClient{
+				rsps: map[string][][]byte{
+					"miIO.info":       {[]byte(`{"result": {"model": "roborock.vacuum.s6"}, "id": 1}`)},
+					"get_custom_mode": {tt.rsp},
+				},
+			}
+			v := &Vacuum{client: c}
+
+			got, err := v.GetFanSpeed()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			// The model is cached.
+			_, err = v.GetFanSpeed()
+			require.NoError(t, err)
+			assert.Equal(t, []string{"miIO.info", "get_custom_mode", "get_custom_mode"}, c.methods())
+		})
+	}
+}
+
+func TestVacuum_SupportedFanSpeeds(t *testing.T) {
+	t.Parallel()
+
+	v := &Vacuum{client: &mockClient{}, modelName: "rockrobo.vacuum.v1", firmwareVersion: "3.3.9_003194"}
+
+	got := v.SupportedFanSpeeds()
+	assert.Equal(t, []FanSpeed{FanSpeedQuiet, FanSpeedBalanced, FanSpeedTurbo, FanSpeedMax}, got)
+	assert.Equal(t, "Quiet", got[0].String())
+}
+
+func TestVacuum_SupportedFanSpeeds_Firmware(t *testing.T) {
+	t.Parallel()
+
+	c := &mockClient{
+		rsps: map[string][][]byte{
+			"miIO.info":       {[]byte(`{"result": {"model": "rockrobo.vacuum.v1", "fw_ver": "3.5.8_002034"}, "id": 1}`)},
+			"get_custom_mode": {[]byte(`{"result": [75], "id": 1}`)},
+		},
+	}
+	v := &Vacuum{client: c}
+
+	got, err := v.GetFanSpeed()
+	require.NoError(t, err)
+	assert.Equal(t, FanSpeedTurbo, got)
+
+	// The model and firmware version are cached.
+	assert.Len(t, v.SupportedFanSpeeds(), 4)
+	assert.Equal(t, []string{"miIO.info", "get_custom_mode"}, c.methods())
+}
@@ -0,0 +1,112 @@
+package vacuum
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// FeatureSet holds the firmware features enabled on a vacuum. Codes not known by this package
+// are preserved.
+type FeatureSet []FeatureCode
+
+// NewFeatureSet creates a FeatureSet from the codes returned by FirmwareFeatures.
+func NewFeatureSet(codes []int) FeatureSet {
+	f := make(FeatureSet, 0, len(codes))
+	for _, c := range codes {
+		f = append(f, FeatureCode(c))
+	}
+
+	return f
+}
+
+func (f FeatureSet) copy() FeatureSet {
+	ret := make(FeatureSet, len(f))
+	copy(ret, f)
+
+	return ret
+}
+
+// Has reports whether all the provided features are enabled.
+func (f FeatureSet) Has(codes ...FeatureCode) bool {
+	for _, c := range codes {
+		found := false
+
+		for _, e := range f {
+			if e == c {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Unknown returns the enabled features which are not known by this package.
+func (f FeatureSet) Unknown() []FeatureCode {
+	unknown := make([]FeatureCode, 0)
+
+	for _, c := range f {
+		if _, ok := featureCodeNames[c]; !ok {
+			unknown = append(unknown, c)
+		}
+	}
+
+	return unknown
+}
+
+func (f FeatureSet) String() string {
+	names := make([]string, 0, len(f))
+	for _, c := range f {
+		names = append(names, c.String())
+	}
+
+	return "[" + strings.Join(names, " ") + "]"
+}
+
+// LEDStatus reports whether the status LED of the vacuum is on. ErrNotSupported is returned if
+// the LEDStatusSwitch feature is not enabled.
+func (v *Vacuum) LEDStatus() (bool, error) {
+	err := v.requireFeatures(FeatureCodeLEDStatusSwitch)
+	if err != nil {
+		return false, err
+	}
+
+	rsp := make([]int, 0)
+
+	err = v.do("get_led_status", nil, &rsp)
+	if err != nil {
+		return false, err
+	}
+
+	if len(rsp) != 1 {
+		return false, ErrUnexpectedResponse
+	}
+
+	return rsp[0] == 1, nil
+}
+
+// SetLEDStatus turns the status LED of the vacuum on or off. ErrNotSupported is returned if
+// the LEDStatusSwitch feature is not enabled.
+func (v *Vacuum) SetLEDStatus(on bool) error {
+	err := v.requireFeatures(FeatureCodeLEDStatusSwitch)
+	if err != nil {
+		return err
+	}
+
+	status := 0
+	if on {
+		status = 1
+	}
+
+	p, err := json.Marshal([]int{status})
+	if err != nil {
+		return err
+	}
+
+	return v.doParams("set_led_status", p)
+}
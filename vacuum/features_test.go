@@ -0,0 +1,141 @@
+package vacuum
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatureSet(t *testing.T) {
+	t.Parallel()
+
+	f := NewFeatureSet([]int{111, 116, 117})
+
+	assert.True(t, f.Has(FeatureCodeFSEndPoint, FeatureCodeMapSegment))
+	assert.False(t, f.Has(FeatureCodeMapSegment, FeatureCodeRemote))
+	assert.Equal(t, []FeatureCode{117}, f.Unknown())
+	assert.Equal(t, "[FSEndPoint MapSegment FeatureCode(117)]", f.String())
+}
+
+func TestVacuum_Features(t *testing.T) {
+	t.Parallel()
+
+	c := &mockClient{
+		rsps: map[string][][]byte{
+			"get_fw_features": {testFeatures},
+		},
+	}
+	v := &Vacuum{client: c}
+
+	got, err := v.Features()
+	require.NoError(t, err)
+	assert.True(t, got.Has(FeatureCodeLEDStatusSwitch))
+
+	// Modifying the features does not change those cached.
+	for i := range got {
+		got[i] = 0
+	}
+
+	got, err = v.Features()
+	require.NoError(t, err)
+	assert.True(t, got.Has(FeatureCodeLEDStatusSwitch))
+	assert.Equal(t, []string{"get_fw_features"}, c.methods())
+}
+
+func TestVacuum_RemoteStart(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		features []byte
+		methods  []string
+		wantErr  error
+	}{
+		"Supported": {
+			features: testFeatures,
+			methods:  []string{"get_fw_features", "app_rc_start"},
+		},
+		"NotSupported": {
+			features: []byte(`{"result": [111, 112], "id": 1}`),
+			methods:  []string{"get_fw_features"},
+			wantErr:  ErrNotSupported,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &mockClient{
+				rsps: map[string][][]byte{
+					"get_fw_features": {tt.features},
+				},
+			}
+			v := &Vacuum{client: c}
+
+			err := v.RemoteStart()
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.methods, c.methods())
+		})
+	}
+}
+
+func TestVacuum_LEDStatus(t *testing.T) {
+	t.Parallel()
+
+	c := &mockClient{
+		rsps: map[string][][]byte{
+			"get_fw_features": {testFeatures},
+			"get_led_status":  {[]byte(`{"result": [1], "id": 1}`)},
+		},
+	}
+	v := &Vacuum{client: c}
+
+	got, err := v.LEDStatus()
+	require.NoError(t, err)
+	assert.True(t, got)
+
+	require.NoError(t, v.SetLEDStatus(false))
+	assert.Equal(t, []string{"get_fw_features", "get_led_status", "set_led_status"}, c.methods())
+	assert.JSONEq(t, `[0]`, string(c.reqs[2].Params))
+}
+
+func TestVacuum_Remote_NotSupported(t *testing.T) {
+	t.Parallel()
+
+	c := &mockClient{
+		rsps: map[string][][]byte{
+			"get_fw_features": {[]byte(`{"result": [111, 112], "id": 1}`)},
+		},
+	}
+	v := &Vacuum{client: c}
+
+	assert.True(t, errors.Is(v.RemoteMove(RemoteMoveParams{}), ErrNotSupported))
+	assert.True(t, errors.Is(v.RemoteEnd(), ErrNotSupported))
+	assert.Equal(t, []string{"get_fw_features"}, c.methods())
+}
+
+func TestVacuum_RemoteStart_NoFeatures(t *testing.T) {
+	t.Parallel()
+
+	c := &mockClient{
+		rsps: map[string][][]byte{
+			"get_fw_features": {[]byte(`{"error": {"code": -32601, "message": "Method not found."}, "id": 1}`)},
+		},
+	}
+	v := &Vacuum{client: c}
+
+	// Firmware which cannot report its features is not gated.
+	require.NoError(t, v.RemoteStart())
+	require.NoError(t, v.RemoteEnd())
+	assert.Equal(t, []string{"get_fw_features", "app_rc_start", "app_rc_end"}, c.methods())
+
+	_, err := v.Features()
+	assert.True(t, errors.Is(err, ErrNotSupported))
+}
@@ -0,0 +1,96 @@
+package vacuum
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// goToStartPolls is the number of status polls to wait for the vacuum to start navigating
+// before giving up.
+const goToStartPolls = 5
+
+// GoTo sends the vacuum to the provided map coordinates (mm).
+func (v *Vacuum) GoTo(x, y int) error {
+	p, err := json.Marshal([]int{x, y})
+	if err != nil {
+		return err
+	}
+
+	return v.doParams("app_goto_target", p)
+}
+
+type GoToOutcome int
+
+const (
+	// GoToOutcomeArrived indicates the vacuum reached the target.
+	GoToOutcomeArrived GoToOutcome = iota
+	// GoToOutcomeFailed indicates the vacuum stopped navigating without reaching the target,
+	// e.g. it never started or was sent elsewhere.
+	GoToOutcomeFailed
+	// GoToOutcomeInterrupted indicates the vacuum stopped navigating due to an error.
+	GoToOutcomeInterrupted
+)
+
+type GoToResult struct {
+	Outcome GoToOutcome
+	// State is the state of the vacuum once it stopped navigating.
+	State StatusCode
+	// ErrorCode is the error reported by the vacuum when the Outcome is GoToOutcomeInterrupted.
+	ErrorCode ErrorCode
+}
+
+// GoToAndWait sends the vacuum to the provided map coordinates (mm) and blocks until it
+// stops navigating or ctx is done.
+func (v *Vacuum) GoToAndWait(ctx context.Context, x, y int) (*GoToResult, error) {
+	err := v.GoTo(x, y)
+	if err != nil {
+		return nil, err
+	}
+
+	ticker := time.NewTicker(v.getPollInterval())
+	defer ticker.Stop()
+
+	started := false
+
+	for polls := 1; ; polls++ {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		s, err := v.Status()
+		if err != nil {
+			return nil, err
+		}
+
+		state := s.State
+		errorCode := s.ErrorCode
+
+		switch {
+		case errorCode != ErrorCodeNoError || state == StatusCodeInError:
+			return &GoToResult{
+				Outcome:   GoToOutcomeInterrupted,
+				State:     state,
+				ErrorCode: errorCode,
+			}, nil
+		case state == StatusCodeGoTo:
+			started = true
+			continue
+		case !started && polls < goToStartPolls:
+			// The vacuum may not have started navigating yet.
+			continue
+		case started && state == StatusCodeIdle:
+			return &GoToResult{
+				Outcome: GoToOutcomeArrived,
+				State:   state,
+			}, nil
+		}
+
+		return &GoToResult{
+			Outcome: GoToOutcomeFailed,
+			State:   state,
+		}, nil
+	}
+}
@@ -0,0 +1,135 @@
+package vacuum
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testStatus(state StatusCode, errorCode ErrorCode) []byte {
+	return []byte(fmt.Sprintf(`
+		{
+			"result": [{
+					"state": %d,
+					"error_code": %d,
+					"battery": 80
+				}
+			],
+			"id": 1
+		}`,
+		state, errorCode,
+	))
+}
+
+func TestVacuum_GoToAndWait(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		statuses [][]byte
+		want     GoToResult
+	}{
+		"arrived": {
+			statuses: [][]byte{
+				testStatus(StatusCodeIdle, ErrorCodeNoError),
+				testStatus(StatusCodeGoTo, ErrorCodeNoError),
+				testStatus(StatusCodeGoTo, ErrorCodeNoError),
+				testStatus(StatusCodeIdle, ErrorCodeNoError),
+			},
+			want: GoToResult{
+				Outcome: GoToOutcomeArrived,
+				State:   StatusCodeIdle,
+			},
+		},
+		"interrupted": {
+			statuses: [][]byte{
+				testStatus(StatusCodeGoTo, ErrorCodeNoError),
+				testStatus(StatusCodeInError, ErrorCodeWheelBlocked),
+			},
+			want: GoToResult{
+				Outcome:   GoToOutcomeInterrupted,
+				State:     StatusCodeInError,
+				ErrorCode: ErrorCodeWheelBlocked,
+			},
+		},
+		"failed": {
+			statuses: [][]byte{
+				testStatus(StatusCodeGoTo, ErrorCodeNoError),
+				testStatus(StatusCodeReturningDock, ErrorCodeNoError),
+			},
+			want: GoToResult{
+				Outcome: GoToOutcomeFailed,
+				State:   StatusCodeReturningDock,
+			},
+		},
+		"never started": {
+			statuses: [][]byte{
+				testStatus(StatusCodeCharging, ErrorCodeNoError),
+			},
+			want: GoToResult{
+				Outcome: GoToOutcomeFailed,
+				State:   StatusCodeCharging,
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &mockClient{
+				rsps: map[string][][]byte{
+					"get_status": tt.statuses,
+				},
+			}
+			v := &Vacuum{
+				client:  c,
+				options: &Options{PollInterval: time.Millisecond},
+			}
+
+			got, err := v.GoToAndWait(context.Background(), 25500, 25500)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, *got)
+			assert.JSONEq(t, `[25500, 25500]`, string(c.reqs[0].Params))
+		})
+	}
+}
+
+func TestVacuum_GoToAndWait_Cancelled(t *testing.T) {
+	t.Parallel()
+
+	v := &Vacuum{
+		client: &mockClient{
+			rsps: map[string][][]byte{
+				"get_status": {testStatus(StatusCodeGoTo, ErrorCodeNoError)},
+			},
+		},
+		options: &Options{PollInterval: time.Millisecond},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := v.GoToAndWait(ctx, 25500, 25500)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestSetPollInterval(t *testing.T) {
+	t.Parallel()
+
+	o := defaultOptions()
+	require.NoError(t, SetPollInterval(100*time.Millisecond)(o))
+	assert.Equal(t, 100*time.Millisecond, o.PollInterval)
+
+	assert.Error(t, SetPollInterval(0)(o))
+
+	v := &Vacuum{options: o}
+	assert.Equal(t, 100*time.Millisecond, v.getPollInterval())
+
+	v = &Vacuum{}
+	assert.Equal(t, DefaultPollInterval, v.getPollInterval())
+}
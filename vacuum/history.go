@@ -0,0 +1,183 @@
+package vacuum
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+type CleanSummary struct {
+	TotalTime time.Duration
+	// TotalArea in m².
+	TotalArea float64
+	Count     int
+	// RecordIDs can be used with CleanRecord to retrieve the details of each clean.
+	RecordIDs []int64
+}
+
+// CleanSummary retrieves a summary of the Vacuum's cleaning history.
+func (v *Vacuum) CleanSummary() (*CleanSummary, error) {
+	rsp := json.RawMessage{}
+
+	err := v.do("get_clean_summary", nil, &rsp)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		timeSeconds int64
+		areaMM2     int64
+		count       int
+		ids         []int64
+	)
+
+	// Older firmware responds with [time, area, count, [ids]] while newer firmware
+	// responds with an object.
+	if isJSONArray(rsp) {
+		fields := make([]json.RawMessage, 0)
+
+		err = json.Unmarshal(rsp, &fields)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(fields) < 4 {
+			return nil, ErrUnexpectedResponse
+		}
+
+		for i, f := range []interface{}{&timeSeconds, &areaMM2, &count, &ids} {
+			err = json.Unmarshal(fields[i], f)
+			if err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		s := struct {
+			CleanTime  int64   `json:"clean_time"`
+			CleanArea  int64   `json:"clean_area"`
+			CleanCount int     `json:"clean_count"`
+			Records    []int64 `json:"records"`
+		}{}
+
+		err = json.Unmarshal(rsp, &s)
+		if err != nil {
+			return nil, err
+		}
+
+		timeSeconds, areaMM2, count, ids = s.CleanTime, s.CleanArea, s.CleanCount, s.Records
+	}
+
+	return &CleanSummary{
+		TotalTime: time.Duration(timeSeconds) * time.Second,
+		TotalArea: mm2ToM2(areaMM2),
+		Count:     count,
+		RecordIDs: ids,
+	}, nil
+}
+
+type CleanRecord struct {
+	Start    time.Time
+	End      time.Time
+	Duration time.Duration
+	// Area in m².
+	Area      float64
+	Error     ErrorCode
+	Completed bool
+	// StartType and DustCollectionStatus are only reported by newer firmware.
+	StartType            int
+	DustCollectionStatus int
+}
+
+type cleanRecord struct {
+	Begin                int64 `json:"begin"`
+	End                  int64 `json:"end"`
+	Duration             int64 `json:"duration"`
+	Area                 int64 `json:"area"`
+	Error                int   `json:"error"`
+	Complete             int   `json:"complete"`
+	StartType            int   `json:"start_type"`
+	DustCollectionStatus int   `json:"dust_collection_status"`
+}
+
+// UnmarshalJSON decodes both the array form [begin, end, duration, area, error, complete]
+// returned by older firmware and the object form returned by newer firmware.
+func (r *cleanRecord) UnmarshalJSON(b []byte) error {
+	if !isJSONArray(b) {
+		type plain cleanRecord
+		return json.Unmarshal(b, (*plain)(r))
+	}
+
+	fields := make([]json.RawMessage, 0)
+
+	err := json.Unmarshal(b, &fields)
+	if err != nil {
+		return err
+	}
+
+	if len(fields) < 6 {
+		return ErrUnexpectedResponse
+	}
+
+	for i, f := range []interface{}{&r.Begin, &r.End, &r.Duration, &r.Area, &r.Error, &r.Complete} {
+		err = json.Unmarshal(fields[i], f)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CleanRecord retrieves the details of a clean, the IDs of the available records can be
+// retrieved via CleanSummary.
+func (v *Vacuum) CleanRecord(id int64) (*CleanRecord, error) {
+	p, err := json.Marshal([]int64{id})
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := make([]cleanRecord, 0)
+
+	err = v.do("get_clean_record", p, &rsp)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rsp) != 1 {
+		return nil, ErrUnexpectedResponse
+	}
+
+	r := rsp[0]
+
+	return &CleanRecord{
+		Start:                time.Unix(r.Begin, 0),
+		End:                  time.Unix(r.End, 0),
+		Duration:             time.Duration(r.Duration) * time.Second,
+		Area:                 mm2ToM2(r.Area),
+		Error:                ErrorCode(r.Error),
+		Completed:            r.Complete == 1,
+		StartType:            r.StartType,
+		DustCollectionStatus: r.DustCollectionStatus,
+	}, nil
+}
+
+// DeleteCleanRecord deletes a clean record from the Vacuum's history.
+func (v *Vacuum) DeleteCleanRecord(id int64) error {
+	p, err := json.Marshal([]int64{id})
+	if err != nil {
+		return err
+	}
+
+	return v.doParams("del_clean_record", p)
+}
+
+// isJSONArray reports whether b holds a JSON array.
+func isJSONArray(b []byte) bool {
+	b = bytes.TrimSpace(b)
+	return len(b) > 0 && b[0] == '['
+}
+
+// mm2ToM2 converts an area reported by the vacuum in mm² to m².
+func mm2ToM2(area int64) float64 {
+	return float64(area) / 1000000
+}
@@ -0,0 +1,132 @@
+package vacuum
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVacuum_CleanSummary(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string][]byte{
+		"array": []byte(`
+			{
+				"result": [174145, 2410150000, 82, [1488240000, 1488153600, 1488067200]],
+				"id": 1
+			}`,
+		),
+		"object": []byte(`
+			{
+				"result": {
+					"clean_time": 174145,
+					"clean_area": 2410150000,
+					"clean_count": 82,
+					"dust_collection_count": 12,
+					"records": [1488240000, 1488153600, 1488067200]
+				},
+				"id": 1
+			}`,
+		),
+	}
+
+	for name, rsp := range tests {
+		rsp := rsp
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			v := &Vacuum{client: &mockClient{rsp: rsp}}
+
+			got, err := v.CleanSummary()
+			require.NoError(t, err)
+			assert.Equal(t, &CleanSummary{
+				TotalTime: 174145 * time.Second,
+				TotalArea: 2410.15,
+				Count:     82,
+				RecordIDs: []int64{1488240000, 1488153600, 1488067200},
+			}, got)
+		})
+	}
+}
+
+func TestVacuum_CleanRecord(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		rsp  []byte
+		want CleanRecord
+	}{
+		"array": {
+			rsp: []byte(`
+				{
+					"result": [[1488347071, 1488347123, 16, 0, 0, 0]],
+					"id": 1
+				}`,
+			),
+			want: CleanRecord{
+				Start:    time.Unix(1488347071, 0),
+				End:      time.Unix(1488347123, 0),
+				Duration: 16 * time.Second,
+			},
+		},
+		"object": {
+			rsp: []byte(`
+				{
+					"result": [{
+							"begin": 1488347071,
+							"end": 1488348071,
+							"duration": 900,
+							"area": 12500000,
+							"error": 5,
+							"complete": 1,
+							"start_type": 2,
+							"clean_type": 1,
+							"finish_reason": 52,
+							"dust_collection_status": 1
+						}
+					],
+					"id": 1
+				}`,
+			),
+			want: CleanRecord{
+				Start:                time.Unix(1488347071, 0),
+				End:                  time.Unix(1488348071, 0),
+				Duration:             900 * time.Second,
+				Area:                 12.5,
+				Error:                ErrorCodeMainBrushBlocked,
+				Completed:            true,
+				StartType:            2,
+				DustCollectionStatus: 1,
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &mockClient{rsp: tt.rsp}
+			v := &Vacuum{client: c}
+
+			got, err := v.CleanRecord(1488347071)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, *got)
+			assert.JSONEq(t, `[1488347071]`, string(c.reqs[0].Params))
+		})
+	}
+}
+
+func TestVacuum_DeleteCleanRecord(t *testing.T) {
+	t.Parallel()
+
+	c := &mockClient{}
+	v := &Vacuum{client: c}
+
+	err := v.DeleteCleanRecord(1616925605)
+	require.NoError(t, err)
+	require.Equal(t, []string{"del_clean_record"}, c.methods())
+	assert.JSONEq(t, `[1616925605]`, string(c.reqs[0].Params))
+}
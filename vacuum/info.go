@@ -1,5 +1,13 @@
 package vacuum
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
 type Info struct {
 	HardwareVersion string `json:"hw_ver"`
 	FirmwareVersion string `json:"fw_ver"`
@@ -31,6 +39,47 @@ func (v *Vacuum) Info() (*Info, error) {
 	return rsp, nil
 }
 
+// model returns the model of the Vacuum, e.g. roborock.vacuum.s5. This is cached after the
+// first call.
+func (v *Vacuum) model() (string, error) {
+	v.cacheMu.Lock()
+	defer v.cacheMu.Unlock()
+
+	if v.modelName != "" {
+		return v.modelName, nil
+	}
+
+	info, err := v.Info()
+	if err != nil {
+		return "", err
+	}
+
+	v.modelName = info.Model
+	v.firmwareVersion = info.FirmwareVersion
+
+	return v.modelName, nil
+}
+
+// firmware returns the firmware version of the Vacuum, e.g. 3.5.8_0021. This is cached after
+// the first call.
+func (v *Vacuum) firmware() (string, error) {
+	v.cacheMu.Lock()
+	defer v.cacheMu.Unlock()
+
+	if v.firmwareVersion != "" {
+		return v.firmwareVersion, nil
+	}
+
+	info, err := v.Info()
+	if err != nil {
+		return "", err
+	}
+
+	v.firmwareVersion = info.FirmwareVersion
+
+	return v.firmwareVersion, nil
+}
+
 type WIFIStatus struct {
 	State         string `json:"state"`
 	AuthFailCount int    `json:"auth_fail_count"`
@@ -89,30 +138,249 @@ func (v *Vacuum) FirmwareFeatures() ([]int, error) {
 	return ret, nil
 }
 
+// Features returns a copy of the enabled firmware features of the Vacuum. This is cached after
+// the first call. ErrNotSupported is returned if the firmware cannot report its features, this
+// is also cached.
+func (v *Vacuum) Features() (FeatureSet, error) {
+	v.cacheMu.Lock()
+	defer v.cacheMu.Unlock()
+
+	if v.features != nil {
+		return v.features.copy(), nil
+	}
+
+	if v.featuresErr != nil {
+		return nil, v.featuresErr
+	}
+
+	features, err := v.FirmwareFeatures()
+	if err != nil {
+		if errors.Is(err, ErrNotSupported) {
+			v.featuresErr = err
+		}
+
+		return nil, err
+	}
+
+	v.features = NewFeatureSet(features)
+
+	return v.features.copy(), nil
+}
+
+// requireFeatures returns ErrNotSupported if any of the provided features are not enabled on the Vacuum.
+//
+// Firmware which cannot report its features (e.g. the v1) is not gated, the method is sent
+// regardless and the vacuum rejects it with ErrNotSupported if it is not supported.
+func (v *Vacuum) requireFeatures(codes ...FeatureCode) error {
+	features, err := v.Features()
+	if errors.Is(err, ErrNotSupported) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for _, c := range codes {
+		if !features.Has(c) {
+			return fmt.Errorf("%w: firmware feature %s is not enabled", ErrNotSupported, c)
+		}
+	}
+
+	return nil
+}
+
 type Status struct {
-	MessageVersion  int `json:"msg_ver"`
-	MessageSequence int `json:"msg_seq"`
-	// See StatusCode for the known vacuum states.
-	State int `json:"state"`
-	// See ErrorCode for the known vacuum errors.
-	ErrorCode int `json:"error_code"`
-
-	Battery                int `json:"battery"`
-	CleanArea              int `json:"clean_area"`
-	CleanTime              int `json:"clean_time"`
-	DNDEnabled             int `json:"dnd_enabled"`
-	FanPower               int `json:"fan_power"`
-	InCleaning             int `json:"in_cleaning"`
-	InFreshState           int `json:"in_fresh_state"`
-	InReturning            int `json:"in_returning"`
-	IsLocating             int `json:"is_locating"`
-	LabStatus              int `json:"lab_status"`
-	LockStatus             int `json:"lock_status"`
-	MapPresent             int `json:"map_present"`
-	MapStatus              int `json:"map_status"`
-	MopForbiddenEnable     int `json:"mop_forbidden_enable"`
-	WaterBoxCarriageStatus int `json:"water_box_carriage_status"`
-	WaterBoxMode           int `json:"water_box_mode"`
+	MessageVersion  int
+	MessageSequence int
+	State           StatusCode
+	ErrorCode       ErrorCode
+
+	Battery int
+	// CleanArea of the current or last clean in m².
+	CleanArea float64
+	// CleanTime of the current or last clean.
+	CleanTime          time.Duration
+	DNDEnabled         bool
+	DockErrorStatus    DockErrorCode
+	DockType           DockType
+	FanPower           int
+	InCleaning         bool
+	InFreshState       bool
+	InReturning        bool
+	IsLocating         bool
+	LabStatus          int
+	LockStatus         bool
+	MapPresent         bool
+	MapStatus          int
+	MopForbiddenEnable bool
+	// WaterBoxCarriageStatus reports whether the mop is attached.
+	WaterBoxCarriageStatus bool
+	WaterBoxMode           int
+	// WaterBoxStatus reports whether the water tank is fitted.
+	WaterBoxStatus bool
+
+	// Raw holds every field of the response, including those not known by this package.
+	Raw map[string]json.RawMessage
+}
+
+type rawStatus struct {
+	MessageVersion         int   `json:"msg_ver"`
+	MessageSequence        int   `json:"msg_seq"`
+	State                  int   `json:"state"`
+	ErrorCode              int   `json:"error_code"`
+	Battery                int   `json:"battery"`
+	CleanArea              int64 `json:"clean_area"`
+	CleanTime              int64 `json:"clean_time"`
+	DNDEnabled             int   `json:"dnd_enabled"`
+	DockErrorStatus        int   `json:"dock_error_status"`
+	DockType               int   `json:"dock_type"`
+	FanPower               int   `json:"fan_power"`
+	InCleaning             int   `json:"in_cleaning"`
+	InFreshState           int   `json:"in_fresh_state"`
+	InReturning            int   `json:"in_returning"`
+	IsLocating             int   `json:"is_locating"`
+	LabStatus              int   `json:"lab_status"`
+	LockStatus             int   `json:"lock_status"`
+	MapPresent             int   `json:"map_present"`
+	MapStatus              int   `json:"map_status"`
+	MopForbiddenEnable     int   `json:"mop_forbidden_enable"`
+	WaterBoxCarriageStatus int   `json:"water_box_carriage_status"`
+	WaterBoxMode           int   `json:"water_box_mode"`
+	WaterBoxStatus         int   `json:"water_box_status"`
+}
+
+func (s *Status) UnmarshalJSON(b []byte) error {
+	r := rawStatus{}
+
+	err := json.Unmarshal(b, &r)
+	if err != nil {
+		return err
+	}
+
+	raw := make(map[string]json.RawMessage)
+
+	err = json.Unmarshal(b, &raw)
+	if err != nil {
+		return err
+	}
+
+	*s = Status{
+		MessageVersion:         r.MessageVersion,
+		MessageSequence:        r.MessageSequence,
+		State:                  StatusCode(r.State),
+		ErrorCode:              ErrorCode(r.ErrorCode),
+		Battery:                r.Battery,
+		CleanArea:              mm2ToM2(r.CleanArea),
+		CleanTime:              time.Duration(r.CleanTime) * time.Second,
+		DNDEnabled:             r.DNDEnabled != 0,
+		DockErrorStatus:        DockErrorCode(r.DockErrorStatus),
+		DockType:               DockType(r.DockType),
+		FanPower:               r.FanPower,
+		InCleaning:             r.InCleaning != 0,
+		InFreshState:           r.InFreshState != 0,
+		InReturning:            r.InReturning != 0,
+		IsLocating:             r.IsLocating != 0,
+		LabStatus:              r.LabStatus,
+		LockStatus:             r.LockStatus != 0,
+		MapPresent:             r.MapPresent != 0,
+		MapStatus:              r.MapStatus,
+		MopForbiddenEnable:     r.MopForbiddenEnable != 0,
+		WaterBoxCarriageStatus: r.WaterBoxCarriageStatus != 0,
+		WaterBoxMode:           r.WaterBoxMode,
+		WaterBoxStatus:         r.WaterBoxStatus != 0,
+		Raw:                    raw,
+	}
+
+	return nil
+}
+
+// MarshalJSON writes the Status using the field names of the device, so a Status can be
+// re-serialised and decoded again. Fields in Raw which are not known are preserved.
+func (s Status) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(rawStatus{
+		MessageVersion:         s.MessageVersion,
+		MessageSequence:        s.MessageSequence,
+		State:                  int(s.State),
+		ErrorCode:              int(s.ErrorCode),
+		Battery:                s.Battery,
+		CleanArea:              int64(math.Round(s.CleanArea * 1000000)),
+		CleanTime:              int64(s.CleanTime / time.Second),
+		DNDEnabled:             boolToInt(s.DNDEnabled),
+		DockErrorStatus:        int(s.DockErrorStatus),
+		DockType:               int(s.DockType),
+		FanPower:               s.FanPower,
+		InCleaning:             boolToInt(s.InCleaning),
+		InFreshState:           boolToInt(s.InFreshState),
+		InReturning:            boolToInt(s.InReturning),
+		IsLocating:             boolToInt(s.IsLocating),
+		LabStatus:              s.LabStatus,
+		LockStatus:             boolToInt(s.LockStatus),
+		MapPresent:             boolToInt(s.MapPresent),
+		MapStatus:              s.MapStatus,
+		MopForbiddenEnable:     boolToInt(s.MopForbiddenEnable),
+		WaterBoxCarriageStatus: boolToInt(s.WaterBoxCarriageStatus),
+		WaterBoxMode:           s.WaterBoxMode,
+		WaterBoxStatus:         boolToInt(s.WaterBoxStatus),
+	})
+	if err != nil || len(s.Raw) == 0 {
+		return b, err
+	}
+
+	known := make(map[string]json.RawMessage)
+
+	err = json.Unmarshal(b, &known)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]json.RawMessage, len(s.Raw)+len(known))
+	for k, v := range s.Raw {
+		fields[k] = v
+	}
+
+	for k, v := range known {
+		fields[k] = v
+	}
+
+	return json.Marshal(fields)
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+// IsDocked reports whether the vacuum is on its dock.
+func (s *Status) IsDocked() bool {
+	switch s.State {
+	case StatusCodeCharging, StatusCodeChargingError, StatusCodeFullyCharged:
+		return true
+	}
+
+	return false
+}
+
+// IsCleaning reports whether the vacuum is cleaning, in any of the clean modes.
+func (s *Status) IsCleaning() bool {
+	switch s.State {
+	case StatusCodeCleaning, StatusCodeSpotCleaning, StatusCodeZoneClean, StatusCodeRoomClean:
+		return true
+	}
+
+	return false
+}
+
+// NeedsAttention reports whether the vacuum, or its dock, has an error which needs to be
+// resolved by the user.
+func (s *Status) NeedsAttention() bool {
+	return s.ErrorCode != ErrorCodeNoError ||
+		s.State == StatusCodeInError ||
+		s.State == StatusCodeChargingError ||
+		s.DockErrorStatus != DockErrorCodeOK
 }
 
 // Status retrieve the over state of the Vacuum.
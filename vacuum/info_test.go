@@ -1,7 +1,9 @@
 package vacuum
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -162,6 +164,96 @@ func TestVacuum_Status(t *testing.T) {
 	got, err := v.Status()
 	require.NoError(t, err)
 	assert.Equal(t, 2, got.MessageVersion)
+	assert.Equal(t, StatusCodeCharging, got.State)
+	assert.Equal(t, ErrorCodeNoError, got.ErrorCode)
+	assert.Equal(t, 0.14, got.CleanArea)
+	assert.Equal(t, 15*time.Second, got.CleanTime)
+	assert.True(t, got.MapPresent)
+	assert.False(t, got.InCleaning)
+	assert.True(t, got.WaterBoxStatus)
+	assert.JSONEq(t, `204`, string(got.Raw["water_box_mode"]))
+	assert.True(t, got.IsDocked())
+	assert.False(t, got.IsCleaning())
+	assert.False(t, got.NeedsAttention())
+}
+
+func TestStatus_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	in := []byte(`{
+		"msg_ver": 2,
+		"state": 5,
+		"battery": 80,
+		"clean_time": 1200,
+		"clean_area": 20142500,
+		"error_code": 0,
+		"in_cleaning": 1,
+		"water_box_status": 1,
+		"fan_power": 102,
+		"unknown_field": [1, 2]
+	}`)
+
+	got := &Status{}
+	require.NoError(t, json.Unmarshal(in, got))
+
+	b, err := json.Marshal(got)
+	require.NoError(t, err)
+
+	fields := make(map[string]json.RawMessage)
+	require.NoError(t, json.Unmarshal(b, &fields))
+	assert.JSONEq(t, `5`, string(fields["state"]))
+	assert.JSONEq(t, `20142500`, string(fields["clean_area"]))
+	assert.JSONEq(t, `1200`, string(fields["clean_time"]))
+	assert.JSONEq(t, `1`, string(fields["in_cleaning"]))
+	assert.JSONEq(t, `[1, 2]`, string(fields["unknown_field"]))
+
+	roundTrip := &Status{}
+	require.NoError(t, json.Unmarshal(b, roundTrip))
+	assert.Equal(t, got.State, roundTrip.State)
+	assert.Equal(t, got.CleanArea, roundTrip.CleanArea)
+	assert.Equal(t, got.CleanTime, roundTrip.CleanTime)
+	assert.Equal(t, got.InCleaning, roundTrip.InCleaning)
+	assert.Equal(t, got.WaterBoxStatus, roundTrip.WaterBoxStatus)
+
+	// InitialStatus embeds the Status by value.
+	b, err = json.Marshal(InitialStatus{Status: *got})
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"status_info":{`)
+	assert.Contains(t, string(b), `"state":5`)
+}
+
+func TestStatus_NeedsAttention(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		status Status
+		want   bool
+	}{
+		"Charging": {
+			status: Status{State: StatusCodeCharging},
+		},
+		"Error": {
+			status: Status{State: StatusCodeIdle, ErrorCode: ErrorCodeMainBrushBlocked},
+			want:   true,
+		},
+		"InError": {
+			status: Status{State: StatusCodeInError},
+			want:   true,
+		},
+		"DockError": {
+			status: Status{State: StatusCodeCharging, DockErrorStatus: DockErrorCodeWaterEmpty},
+			want:   true,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, tt.status.NeedsAttention())
+		})
+	}
 }
 
 func TestVacuum_InitialStatus(t *testing.T) {
@@ -212,6 +304,7 @@ func TestVacuum_InitialStatus(t *testing.T) {
 	got, err := v.InitialStatus()
 	require.NoError(t, err)
 	assert.Equal(t, "custom_A.03.0070_CE", got.Locale.Name)
+	assert.Equal(t, StatusCodeCharging, got.Status.State)
 }
 
 func TestVacuum_NetworkInfo(t *testing.T) {
@@ -1,6 +1,22 @@
 package vacuum
 
-import "encoding/json"
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/l-ross/xiaomi/vacuum/rrmap"
+)
+
+var (
+	ErrMapNotReady   = errors.New("map is not ready, retry later")
+	ErrNoMapResolver = errors.New("no map resolver configured, see SetMapResolver")
+)
 
 func (v *Vacuum) Map() (string, error) {
 	rsp := &json.RawMessage{}
@@ -11,3 +27,174 @@ func (v *Vacuum) Map() (string, error) {
 
 	return string(*rsp), nil
 }
+
+// MapResolver resolves the name of a map, as returned by the vacuum, to the URL it can be
+// downloaded from. Usually this is done via the Xiaomi cloud.
+type MapResolver interface {
+	ResolveMap(ctx context.Context, name string) (string, error)
+}
+
+// MapResolverFunc allows a function to be used as a MapResolver.
+type MapResolverFunc func(ctx context.Context, name string) (string, error)
+
+func (f MapResolverFunc) ResolveMap(ctx context.Context, name string) (string, error) {
+	return f(ctx, name)
+}
+
+// HTTPMapResolver resolves maps to a path under BaseURL, for use with a local HTTP server
+// standing in for the cloud.
+type HTTPMapResolver struct {
+	BaseURL string
+}
+
+func (r *HTTPMapResolver) ResolveMap(_ context.Context, name string) (string, error) {
+	// Map names are URL encoded by the vacuum, e.g. robomap%2F12345%2F0
+	unescaped, err := url.PathUnescape(name)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(r.BaseURL, "/") + "/" + unescaped, nil
+}
+
+// FallbackMapResolver returns a MapResolver which tries each resolver in turn, returning the
+// first URL successfully resolved. If every resolver fails the error wraps that of the last
+// resolver, the earlier errors are included in its message.
+func FallbackMapResolver(resolvers ...MapResolver) MapResolver {
+	return MapResolverFunc(func(ctx context.Context, name string) (string, error) {
+		if len(resolvers) == 0 {
+			return "", fmt.Errorf("failed to resolve map %s: no resolvers", name)
+		}
+
+		errs := make([]string, 0, len(resolvers))
+
+		var lastErr error
+
+		for _, r := range resolvers {
+			u, err := r.ResolveMap(ctx, name)
+			if err == nil {
+				return u, nil
+			}
+
+			if lastErr != nil {
+				errs = append(errs, lastErr.Error())
+			}
+
+			lastErr = err
+		}
+
+		if len(errs) == 0 {
+			return "", fmt.Errorf("failed to resolve map %s: %w", name, lastErr)
+		}
+
+		return "", fmt.Errorf("failed to resolve map %s: %s; %w", name, strings.Join(errs, "; "), lastErr)
+	})
+}
+
+// DownloadMap downloads and parses the current map.
+//
+// The vacuum only provides the name of the map, a MapResolver must be set via
+// SetMapResolver to resolve this to a URL.
+func (v *Vacuum) DownloadMap(ctx context.Context) (*rrmap.Map, error) {
+	return v.downloadMap(ctx, "get_map_v1")
+}
+
+// DownloadFreshMap downloads and parses a freshly generated map, see DownloadMap.
+func (v *Vacuum) DownloadFreshMap(ctx context.Context) (*rrmap.Map, error) {
+	return v.downloadMap(ctx, "get_fresh_map_v1")
+}
+
+// DownloadPersistMap downloads and parses the saved (persistent) map, see DownloadMap.
+func (v *Vacuum) DownloadPersistMap(ctx context.Context) (*rrmap.Map, error) {
+	return v.downloadMap(ctx, "get_persist_map_v1")
+}
+
+func (v *Vacuum) downloadMap(ctx context.Context, method string) (*rrmap.Map, error) {
+	o := v.getOptions()
+
+	if o.MapResolver == nil {
+		return nil, ErrNoMapResolver
+	}
+
+	name, err := v.mapName(method)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := o.MapResolver.ResolveMap(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := o.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download map: %s", rsp.Status)
+	}
+
+	b, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return rrmap.ParseBytes(b)
+}
+
+// mapName retrieves the name of the map via the provided method.
+func (v *Vacuum) mapName(method string) (string, error) {
+	rsp := make([]string, 0)
+
+	err := v.do(method, nil, &rsp)
+	if err != nil {
+		return "", err
+	}
+
+	if len(rsp) != 1 {
+		return "", ErrUnexpectedResponse
+	}
+
+	// The vacuum responds with retry while the map is being generated.
+	if rsp[0] == "retry" {
+		return "", ErrMapNotReady
+	}
+
+	return rsp[0], nil
+}
+
+// editMap runs fn within a map editing session. If fn returns an error the session is
+// ended without committing the changes, otherwise the changes are committed.
+func (v *Vacuum) editMap(fn func() error) error {
+	err := v.doSimple("start_edit_map")
+	if err != nil {
+		return fmt.Errorf("failed to start map edit: %w", err)
+	}
+
+	err = fn()
+	if err != nil {
+		// Ending the session with 0 discards any edits made within it.
+		p, _ := json.Marshal([]int{0})
+
+		rollbackErr := v.doParams("end_edit_map", p)
+		if rollbackErr != nil {
+			return fmt.Errorf("%w (failed to roll back map edit: %v)", err, rollbackErr)
+		}
+
+		return err
+	}
+
+	err = v.doSimple("end_edit_map")
+	if err != nil {
+		return fmt.Errorf("failed to end map edit: %w", err)
+	}
+
+	return nil
+}
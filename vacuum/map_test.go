@@ -0,0 +1,134 @@
+package vacuum
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/l-ross/xiaomi/vacuum/rrmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMapServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	b, err := os.ReadFile("rrmap/testdata/map.rrmap.gz")
+	require.NoError(t, err)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/robomap/74056/0" {
+			http.NotFound(w, r)
+			return
+		}
+
+		_, _ = w.Write(b)
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func TestVacuum_DownloadMap(t *testing.T) {
+	t.Parallel()
+
+	s := testMapServer(t)
+
+	tests := map[string]struct {
+		download func(v *Vacuum, ctx context.Context) (*rrmap.Map, error)
+		method   string
+	}{
+		"map":         {download: (*Vacuum).DownloadMap, method: "get_map_v1"},
+		"fresh map":   {download: (*Vacuum).DownloadFreshMap, method: "get_fresh_map_v1"},
+		"persist map": {download: (*Vacuum).DownloadPersistMap, method: "get_persist_map_v1"},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &mockClient{
+				rsp: []byte(`{"result": ["robomap%2F74056%2F0"], "id": 1}`),
+			}
+
+			failing := MapResolverFunc(func(ctx context.Context, name string) (string, error) {
+				return "", errors.New("cloud unavailable")
+			})
+
+			v := &Vacuum{
+				client: c,
+				options: &Options{
+					MapResolver: FallbackMapResolver(failing, &HTTPMapResolver{BaseURL: s.URL}),
+					HTTPClient:  s.Client(),
+				},
+			}
+
+			m, err := tt.download(v, context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, []string{tt.method}, c.methods())
+			assert.Equal(t, &rrmap.Point{X: 25600, Y: 25600}, m.Charger)
+		})
+	}
+}
+
+func TestVacuum_DownloadMap_Errors(t *testing.T) {
+	t.Parallel()
+
+	s := testMapServer(t)
+
+	tests := map[string]struct {
+		rsp     []byte
+		options *Options
+		wantErr error
+	}{
+		"no resolver": {
+			rsp:     []byte(`{"result": ["robomap%2F74056%2F0"], "id": 1}`),
+			wantErr: ErrNoMapResolver,
+		},
+		"retry": {
+			rsp:     []byte(`{"result": ["retry"], "id": 1}`),
+			options: &Options{MapResolver: &HTTPMapResolver{BaseURL: s.URL}, HTTPClient: s.Client()},
+			wantErr: ErrMapNotReady,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			v := &Vacuum{
+				client:  &mockClient{rsp: tt.rsp},
+				options: tt.options,
+			}
+
+			_, err := v.DownloadMap(context.Background())
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestFallbackMapResolver_Errors(t *testing.T) {
+	t.Parallel()
+
+	failing := MapResolverFunc(func(ctx context.Context, name string) (string, error) {
+		return "", errors.New("cloud unavailable")
+	})
+	cancelled := MapResolverFunc(func(ctx context.Context, name string) (string, error) {
+		return "", ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := FallbackMapResolver(failing, cancelled).ResolveMap(ctx, "map")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Contains(t, err.Error(), "cloud unavailable")
+
+	_, err = FallbackMapResolver().ResolveMap(ctx, "map")
+	assert.Error(t, err)
+}
@@ -0,0 +1,170 @@
+// Package mapcoord converts between the coordinate systems used by Roborock vacuum maps.
+//
+// The vacuum's commands (zoned cleaning, go to, forbidden zones, etc.) take map coordinates
+// in mm. Maps parsed by the rrmap package contain an Image where each pixel covers
+// rrmap.PixelSize mm, offset from the origin of the map by the image's Left and Top. When
+// displayed the y axis of the image is flipped, so that the top of the map is at the top of
+// the output.
+package mapcoord
+
+import (
+	"errors"
+	"image"
+	"math"
+
+	"github.com/l-ross/xiaomi/vacuum/rrmap"
+)
+
+var ErrSegmentNotFound = errors.New("segment not found in map image")
+
+// Converter converts between map coordinates (mm) and pixels of a rendered view of the map.
+type Converter struct {
+	img *rrmap.Image
+
+	// Scale is the number of output pixels per map pixel.
+	Scale int
+	// Bounds is the area of the map image in the view, in map pixels.
+	Bounds image.Rectangle
+}
+
+// New returns a Converter for a view of the whole image with one output pixel per map pixel.
+func New(img *rrmap.Image) *Converter {
+	return &Converter{
+		img:    img,
+		Scale:  1,
+		Bounds: image.Rect(0, 0, img.Width, img.Height),
+	}
+}
+
+// View returns a Converter for a view of the provided area of the map image at the given scale.
+// Scales below 1 are treated as 1.
+func (c *Converter) View(scale int, bounds image.Rectangle) *Converter {
+	if scale < 1 {
+		scale = 1
+	}
+
+	return &Converter{
+		img:    c.img,
+		Scale:  scale,
+		Bounds: bounds,
+	}
+}
+
+// Size returns the size of the view in output pixels.
+func (c *Converter) Size() image.Point {
+	return c.Bounds.Size().Mul(c.Scale)
+}
+
+// ToPixel converts a point in map coordinates to output pixels.
+func (c *Converter) ToPixel(p rrmap.Point) (float64, float64) {
+	px := float64(p.X)/rrmap.PixelSize - float64(c.img.Left)
+	py := float64(p.Y)/rrmap.PixelSize - float64(c.img.Top)
+
+	return (px - float64(c.Bounds.Min.X)) * float64(c.Scale),
+		(float64(c.Bounds.Max.Y) - py) * float64(c.Scale)
+}
+
+// ToMap converts a point in output pixels to map coordinates.
+func (c *Converter) ToMap(x, y float64) rrmap.Point {
+	px := x/float64(c.Scale) + float64(c.Bounds.Min.X) + float64(c.img.Left)
+	py := float64(c.Bounds.Max.Y) - y/float64(c.Scale) + float64(c.img.Top)
+
+	return rrmap.Point{
+		X: int(math.Round(px * rrmap.PixelSize)),
+		Y: int(math.Round(py * rrmap.PixelSize)),
+	}
+}
+
+// PixelRect returns the area of the output covered by the map image pixel x, y.
+func (c *Converter) PixelRect(x, y int) image.Rectangle {
+	ox := (x - c.Bounds.Min.X) * c.Scale
+	oy := (c.Bounds.Max.Y - 1 - y) * c.Scale
+
+	return image.Rect(ox, oy, ox+c.Scale, oy+c.Scale)
+}
+
+// Length converts a distance in map coordinates (mm) to output pixels.
+func (c *Converter) Length(mm float64) float64 {
+	return mm / rrmap.PixelSize * float64(c.Scale)
+}
+
+// ImagePixel returns the map image pixel containing the point.
+func (c *Converter) ImagePixel(p rrmap.Point) image.Point {
+	return image.Pt(
+		floorDiv(p.X, rrmap.PixelSize)-c.img.Left,
+		floorDiv(p.Y, rrmap.PixelSize)-c.img.Top,
+	)
+}
+
+// SegmentAt returns the segment (room) containing the point, 0 if it is not within a segment.
+func (c *Converter) SegmentAt(p rrmap.Point) int {
+	ip := c.ImagePixel(p)
+	_, segment := c.img.At(ip.X, ip.Y)
+
+	return segment
+}
+
+// InRoom reports whether the point is within the segment (room).
+func (c *Converter) InRoom(p rrmap.Point, segment int) bool {
+	return segment != 0 && c.SegmentAt(p) == segment
+}
+
+// RoomBounds returns the bounding rectangle of the segment (room) in map coordinates.
+func (c *Converter) RoomBounds(segment int) (Rect, error) {
+	b := image.Rectangle{}
+
+	for y := 0; y < c.img.Height; y++ {
+		for x := 0; x < c.img.Width; x++ {
+			if _, s := c.img.At(x, y); s == segment && s != 0 {
+				b = b.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+
+	if b.Empty() {
+		return Rect{}, ErrSegmentNotFound
+	}
+
+	return Rect{
+		Min: rrmap.Point{
+			X: (b.Min.X + c.img.Left) * rrmap.PixelSize,
+			Y: (b.Min.Y + c.img.Top) * rrmap.PixelSize,
+		},
+		Max: rrmap.Point{
+			X: (b.Max.X + c.img.Left) * rrmap.PixelSize,
+			Y: (b.Max.Y + c.img.Top) * rrmap.PixelSize,
+		},
+	}, nil
+}
+
+// ToRoom converts a point in map coordinates to coordinates relative to the bottom left of
+// the segment's (room's) bounding rectangle.
+func (c *Converter) ToRoom(p rrmap.Point, segment int) (rrmap.Point, error) {
+	r, err := c.RoomBounds(segment)
+	if err != nil {
+		return rrmap.Point{}, err
+	}
+
+	return rrmap.Point{X: p.X - r.Min.X, Y: p.Y - r.Min.Y}, nil
+}
+
+// FromRoom converts a point relative to the bottom left of the segment's (room's) bounding
+// rectangle to map coordinates.
+func (c *Converter) FromRoom(p rrmap.Point, segment int) (rrmap.Point, error) {
+	r, err := c.RoomBounds(segment)
+	if err != nil {
+		return rrmap.Point{}, err
+	}
+
+	return rrmap.Point{X: p.X + r.Min.X, Y: p.Y + r.Min.Y}, nil
+}
+
+// floorDiv divides rounding towards negative infinity.
+func floorDiv(a, b int) int {
+	d := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		d--
+	}
+
+	return d
+}
@@ -0,0 +1,128 @@
+package mapcoord
+
+import (
+	"image"
+	"testing"
+
+	"github.com/l-ross/xiaomi/vacuum/rrmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	wall = 1
+	seg1 = 1<<3 | 7
+	seg2 = 2<<3 | 7
+)
+
+// testImage returns a 4x3 image at pixel offset 500, 500 (25000mm, 25000mm) with segment 1
+// on the left and segment 2 on the right of the middle row.
+func testImage() *rrmap.Image {
+	return &rrmap.Image{
+		Top:    500,
+		Left:   500,
+		Width:  4,
+		Height: 3,
+		Pixels: []byte{
+			wall, wall, wall, wall,
+			seg1, seg1, seg2, seg2,
+			wall, wall, wall, wall,
+		},
+	}
+}
+
+func TestConverter_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]*Converter{
+		"full":    New(testImage()),
+		"scaled":  New(testImage()).View(4, image.Rect(0, 0, 4, 3)),
+		"cropped": New(testImage()).View(2, image.Rect(1, 1, 3, 2)),
+	}
+
+	for name, c := range tests {
+		c := c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			p := rrmap.Point{X: 25100, Y: 25050}
+
+			x, y := c.ToPixel(p)
+			assert.Equal(t, p, c.ToMap(x, y))
+		})
+	}
+}
+
+func TestConverter_ToPixel(t *testing.T) {
+	t.Parallel()
+
+	c := New(testImage()).View(2, image.Rect(0, 0, 4, 3))
+
+	// The bottom left of the map is the bottom left of the output.
+	x, y := c.ToPixel(rrmap.Point{X: 25000, Y: 25000})
+	assert.Equal(t, [2]float64{0, 6}, [2]float64{x, y})
+
+	// The top right of the map is the top right of the output.
+	x, y = c.ToPixel(rrmap.Point{X: 25200, Y: 25150})
+	assert.Equal(t, [2]float64{8, 0}, [2]float64{x, y})
+
+	assert.Equal(t, image.Pt(8, 6), c.Size())
+	assert.Equal(t, image.Rect(0, 4, 2, 6), c.PixelRect(0, 0))
+}
+
+func TestConverter_View_InvalidScale(t *testing.T) {
+	t.Parallel()
+
+	c := New(testImage()).View(0, image.Rect(0, 0, 4, 3))
+	assert.Equal(t, 1, c.Scale)
+	assert.Equal(t, rrmap.Point{X: 25000, Y: 25150}, c.ToMap(0, 0))
+}
+
+func TestConverter_Rooms(t *testing.T) {
+	t.Parallel()
+
+	c := New(testImage())
+
+	assert.True(t, c.InRoom(rrmap.Point{X: 25025, Y: 25075}, 1))
+	assert.False(t, c.InRoom(rrmap.Point{X: 25025, Y: 25075}, 2))
+	assert.True(t, c.InRoom(rrmap.Point{X: 25199, Y: 25099}, 2))
+	assert.Equal(t, 0, c.SegmentAt(rrmap.Point{X: 25025, Y: 25025}))
+	assert.Equal(t, 0, c.SegmentAt(rrmap.Point{X: 0, Y: 0}))
+
+	r, err := c.RoomBounds(2)
+	require.NoError(t, err)
+	assert.Equal(t, Rect{Min: rrmap.Point{X: 25100, Y: 25050}, Max: rrmap.Point{X: 25200, Y: 25100}}, r)
+
+	rel, err := c.ToRoom(rrmap.Point{X: 25150, Y: 25075}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, rrmap.Point{X: 50, Y: 25}, rel)
+
+	abs, err := c.FromRoom(rel, 2)
+	require.NoError(t, err)
+	assert.Equal(t, rrmap.Point{X: 25150, Y: 25075}, abs)
+
+	_, err = c.RoomBounds(3)
+	assert.ErrorIs(t, err, ErrSegmentNotFound)
+}
+
+func TestShapes(t *testing.T) {
+	t.Parallel()
+
+	r := NewRect(rrmap.Point{X: 200, Y: 100}, rrmap.Point{X: 100, Y: 200})
+	assert.Equal(t, Rect{Min: rrmap.Point{X: 100, Y: 100}, Max: rrmap.Point{X: 200, Y: 200}}, r)
+	assert.True(t, r.Contains(rrmap.Point{X: 150, Y: 150}))
+	assert.False(t, r.Contains(rrmap.Point{X: 250, Y: 150}))
+	assert.False(t, r.Empty())
+
+	poly := r.Polygon()
+	assert.True(t, PolygonContains(poly[:], rrmap.Point{X: 150, Y: 150}))
+	assert.False(t, PolygonContains(poly[:], rrmap.Point{X: 50, Y: 150}))
+	assert.Equal(t, r, PolygonBounds(poly[:]))
+
+	triangle := []rrmap.Point{{X: 0, Y: 0}, {X: 100, Y: 0}, {X: 0, Y: 100}}
+	assert.True(t, PolygonContains(triangle, rrmap.Point{X: 20, Y: 20}))
+	assert.False(t, PolygonContains(triangle, rrmap.Point{X: 80, Y: 80}))
+
+	c := New(testImage()).View(2, image.Rect(0, 0, 4, 3))
+	assert.Equal(t, Rect{Min: rrmap.Point{X: 25000, Y: 25000}, Max: rrmap.Point{X: 25100, Y: 25150}}, c.RectFromPixels(4, 0, 0, 6))
+}
@@ -0,0 +1,108 @@
+package mapcoord
+
+import "github.com/l-ross/xiaomi/vacuum/rrmap"
+
+// Rect is a rectangle in map coordinates, Min is the bottom left and Max the top right.
+type Rect struct {
+	Min rrmap.Point
+	Max rrmap.Point
+}
+
+// NewRect returns the rectangle with opposite corners a and b.
+func NewRect(a, b rrmap.Point) Rect {
+	r := Rect{Min: a, Max: b}
+
+	if r.Min.X > r.Max.X {
+		r.Min.X, r.Max.X = r.Max.X, r.Min.X
+	}
+
+	if r.Min.Y > r.Max.Y {
+		r.Min.Y, r.Max.Y = r.Max.Y, r.Min.Y
+	}
+
+	return r
+}
+
+// RectFromPixels returns the rectangle in map coordinates with opposite corners at the
+// output pixels x0, y0 and x1, y1, e.g. an area selected on a rendered map.
+func (c *Converter) RectFromPixels(x0, y0, x1, y1 float64) Rect {
+	return NewRect(c.ToMap(x0, y0), c.ToMap(x1, y1))
+}
+
+// Contains reports whether p is within the rectangle, including its edges.
+func (r Rect) Contains(p rrmap.Point) bool {
+	return p.X >= r.Min.X && p.X <= r.Max.X && p.Y >= r.Min.Y && p.Y <= r.Max.Y
+}
+
+// Empty reports whether the rectangle has no area.
+func (r Rect) Empty() bool {
+	return r.Min.X >= r.Max.X || r.Min.Y >= r.Max.Y
+}
+
+// Zone returns the rectangle as an rrmap.Zone.
+func (r Rect) Zone() rrmap.Zone {
+	return rrmap.Zone{Min: r.Min, Max: r.Max}
+}
+
+// Polygon returns the corners of the rectangle, anti-clockwise from the bottom left.
+func (r Rect) Polygon() rrmap.Polygon {
+	return rrmap.Polygon{
+		r.Min,
+		{X: r.Max.X, Y: r.Min.Y},
+		r.Max,
+		{X: r.Min.X, Y: r.Max.Y},
+	}
+}
+
+// PolygonContains reports whether p is within the polygon using the even-odd rule.
+func PolygonContains(polygon []rrmap.Point, p rrmap.Point) bool {
+	inside := false
+
+	for i := range polygon {
+		a := polygon[i]
+		b := polygon[(i+1)%len(polygon)]
+
+		if (a.Y > p.Y) != (b.Y > p.Y) {
+			x := float64(a.X) + float64(p.Y-a.Y)/float64(b.Y-a.Y)*float64(b.X-a.X)
+			if float64(p.X) < x {
+				inside = !inside
+			}
+		}
+	}
+
+	return inside
+}
+
+// PolygonBounds returns the bounding rectangle of the polygon.
+func PolygonBounds(polygon []rrmap.Point) Rect {
+	if len(polygon) == 0 {
+		return Rect{}
+	}
+
+	r := Rect{Min: polygon[0], Max: polygon[0]}
+
+	for _, p := range polygon[1:] {
+		r = NewRect(
+			rrmap.Point{X: minInt(r.Min.X, p.X), Y: minInt(r.Min.Y, p.Y)},
+			rrmap.Point{X: maxInt(r.Max.X, p.X), Y: maxInt(r.Max.Y, p.Y)},
+		)
+	}
+
+	return r
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
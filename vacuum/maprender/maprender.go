@@ -0,0 +1,87 @@
+// Package maprender renders maps parsed by the rrmap package as PNG or SVG images.
+package maprender
+
+import (
+	"errors"
+	"fmt"
+	"image"
+
+	"github.com/l-ross/xiaomi/vacuum/mapcoord"
+	"github.com/l-ross/xiaomi/vacuum/rrmap"
+)
+
+var ErrNoImage = errors.New("map does not contain an image")
+
+const (
+	// robotRadius and chargerRadius are the sizes of the icons in map coordinates (mm).
+	robotRadius   = 175
+	chargerRadius = 100
+	// obstacleSize is the size of obstacle markers in map coordinates (mm).
+	obstacleSize = 100
+)
+
+// canvas is the area of the map being rendered.
+type canvas struct {
+	*mapcoord.Converter
+
+	img *rrmap.Image
+}
+
+func newCanvas(m *rrmap.Map, opts []Option) (*canvas, *Options, error) {
+	o := defaultOptions()
+
+	for _, opt := range opts {
+		err := opt(o)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error setting option: %w", err)
+		}
+	}
+
+	if m.Image == nil || m.Image.Width == 0 || m.Image.Height == 0 {
+		return nil, nil, ErrNoImage
+	}
+
+	bounds := image.Rect(0, 0, m.Image.Width, m.Image.Height)
+
+	if o.Crop {
+		bounds = contentBounds(m.Image)
+	}
+
+	c := &canvas{
+		Converter: mapcoord.New(m.Image).View(o.Scale, bounds),
+		img:       m.Image,
+	}
+
+	return c, o, nil
+}
+
+// NewConverter returns a Converter between map coordinates and pixels of the image that
+// would be rendered with the provided options. This can be used to convert a position
+// selected on a rendered map to map coordinates.
+func NewConverter(m *rrmap.Map, opts ...Option) (*mapcoord.Converter, error) {
+	c, _, err := newCanvas(m, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Converter, nil
+}
+
+// contentBounds returns the area of img which is not outside of the map.
+func contentBounds(img *rrmap.Image) image.Rectangle {
+	b := image.Rectangle{}
+
+	for y := 0; y < img.Height; y++ {
+		for x := 0; x < img.Width; x++ {
+			if t, _ := img.At(x, y); t != rrmap.PixelOutside {
+				b = b.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+
+	if b.Empty() {
+		return image.Rect(0, 0, img.Width, img.Height)
+	}
+
+	return b
+}
@@ -0,0 +1,149 @@
+package maprender
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/l-ross/xiaomi/vacuum/rrmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	wall = 1
+	seg1 = 1<<3 | 7
+	seg2 = 2<<3 | 7
+)
+
+// testMap returns a map with a 6x4 image, the bottom row is outside of the map and the
+// remaining rows are walls surrounding two segments.
+func testMap() *rrmap.Map {
+	return &rrmap.Map{
+		Image: &rrmap.Image{
+			Top:    500,
+			Left:   500,
+			Width:  6,
+			Height: 4,
+			Pixels: []byte{
+				0, 0, 0, 0, 0, 0,
+				wall, wall, wall, wall, wall, wall,
+				wall, seg1, seg1, seg2, seg2, wall,
+				wall, wall, wall, wall, wall, wall,
+			},
+		},
+		Charger: &rrmap.Point{X: 25075, Y: 25125},
+		Robot:   &rrmap.Position{Point: rrmap.Point{X: 25225, Y: 25125}},
+		Path: &rrmap.Path{
+			Points: []rrmap.Point{{X: 25075, Y: 25125}, {X: 25225, Y: 25125}},
+		},
+		ForbiddenZones: []rrmap.Polygon{
+			{{X: 25150, Y: 25100}, {X: 25200, Y: 25100}, {X: 25200, Y: 25150}, {X: 25150, Y: 25150}},
+		},
+		VirtualWalls: []rrmap.Wall{
+			{Start: rrmap.Point{X: 25150, Y: 25100}, End: rrmap.Point{X: 25150, Y: 25150}},
+		},
+	}
+}
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	theme := &Theme{
+		Wall:     color.NRGBA{A: 0xff},
+		Segments: []color.NRGBA{{R: 0xff, A: 0xff}, {G: 0xff, A: 0xff}},
+	}
+
+	tests := map[string]struct {
+		opts     []Option
+		wantSize [2]int
+		// wantSeg1 is the location of a pixel within segment 1.
+		wantSeg1 [2]int
+	}{
+		"crop": {
+			opts:     []Option{SetScale(2), SetTheme(theme)},
+			wantSize: [2]int{12, 6},
+			wantSeg1: [2]int{2, 2},
+		},
+		"no crop": {
+			opts:     []Option{SetScale(1), SetCrop(false), SetTheme(theme)},
+			wantSize: [2]int{6, 4},
+			wantSeg1: [2]int{1, 1},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			img, err := Render(testMap(), tt.opts...)
+			require.NoError(t, err)
+
+			b := img.Bounds()
+			assert.Equal(t, tt.wantSize, [2]int{b.Dx(), b.Dy()})
+
+			// The top of the output is the top of the map, which is the last image row.
+			assert.Equal(t, color.NRGBA{A: 0xff}, color.NRGBAModel.Convert(img.At(0, 0)))
+			assert.Equal(t, color.NRGBA{R: 0xff, A: 0xff}, color.NRGBAModel.Convert(img.At(tt.wantSeg1[0], tt.wantSeg1[1])))
+		})
+	}
+}
+
+func TestRender_NoImage(t *testing.T) {
+	t.Parallel()
+
+	_, err := Render(&rrmap.Map{})
+	assert.ErrorIs(t, err, ErrNoImage)
+
+	_, err = Render(testMap(), SetScale(0))
+	assert.Error(t, err)
+}
+
+func TestEncodePNG(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+
+	err := EncodePNG(buf, testMap(), SetTheme(DarkTheme))
+	require.NoError(t, err)
+
+	img, err := png.Decode(buf)
+	require.NoError(t, err)
+	assert.Equal(t, 6*DefaultScale, img.Bounds().Dx())
+}
+
+func TestEncodeSVG(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+
+	err := EncodeSVG(buf, testMap())
+	require.NoError(t, err)
+
+	svg := buf.String()
+	assert.True(t, strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="12"`))
+	assert.Contains(t, svg, `<polygon points="12.0,8.0 16.0,8.0 16.0,4.0 12.0,4.0"`)
+	assert.Contains(t, svg, `<polyline points="6.0,6.0 18.0,6.0"`)
+	assert.Equal(t, 2, strings.Count(svg, "<circle"))
+	assert.True(t, strings.HasSuffix(svg, "</svg>\n"))
+}
+
+func TestEncodeSVG_GoToPath(t *testing.T) {
+	t.Parallel()
+
+	m := testMap()
+	m.GoToPath = &rrmap.Path{
+		Points: []rrmap.Point{{X: 25225, Y: 25125}, {X: 25175, Y: 25125}},
+	}
+
+	buf := &bytes.Buffer{}
+
+	err := EncodeSVG(buf, m)
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), `<polyline points="18.0,6.0 14.0,6.0"`)
+	assert.Equal(t, 2, strings.Count(buf.String(), "<polyline"))
+}
@@ -0,0 +1,131 @@
+package maprender
+
+import (
+	"errors"
+	"image/color"
+)
+
+const DefaultScale = 4
+
+type Options struct {
+	// Scale is the number of output pixels per map pixel.
+	Scale int
+	// Crop the output to the area of the map which contains content.
+	Crop  bool
+	Theme *Theme
+}
+
+type Option func(*Options) error
+
+// defaultOptions defines the default Options for rendering. To override these provide the
+// appropriate Option when rendering.
+func defaultOptions() *Options {
+	return &Options{
+		Scale: DefaultScale,
+		Crop:  true,
+		Theme: DefaultTheme,
+	}
+}
+
+// SetScale sets the number of output pixels per map pixel.
+func SetScale(scale int) Option {
+	return func(o *Options) error {
+		if scale < 1 {
+			return errors.New("scale must be at least 1")
+		}
+
+		o.Scale = scale
+		return nil
+	}
+}
+
+// SetCrop sets whether the output is cropped to the area of the map which contains content.
+func SetCrop(crop bool) Option {
+	return func(o *Options) error {
+		o.Crop = crop
+		return nil
+	}
+}
+
+// SetTheme sets the colours used when rendering.
+func SetTheme(t *Theme) Option {
+	return func(o *Options) error {
+		if t == nil {
+			return errors.New("theme must not be nil")
+		}
+
+		o.Theme = t
+		return nil
+	}
+}
+
+type Theme struct {
+	Background  color.NRGBA
+	Floor       color.NRGBA
+	Wall        color.NRGBA
+	Path        color.NRGBA
+	GoToPath    color.NRGBA
+	Charger     color.NRGBA
+	Robot       color.NRGBA
+	Obstacle    color.NRGBA
+	NoGoZone    color.NRGBA
+	NoMopZone   color.NRGBA
+	VirtualWall color.NRGBA
+	// Segments are the colours used for each segment (room), these are used in turn
+	// if there are more segments than colours.
+	Segments []color.NRGBA
+}
+
+var DefaultTheme = &Theme{
+	Background:  color.NRGBA{},
+	Floor:       color.NRGBA{R: 0x56, G: 0xaf, B: 0xfc, A: 0xff},
+	Wall:        color.NRGBA{R: 0x24, G: 0x24, B: 0x24, A: 0xff},
+	Path:        color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+	GoToPath:    color.NRGBA{R: 0x00, G: 0xa0, B: 0x50, A: 0xff},
+	Charger:     color.NRGBA{R: 0x2e, G: 0xcc, B: 0x71, A: 0xff},
+	Robot:       color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+	Obstacle:    color.NRGBA{R: 0xf3, G: 0x9c, B: 0x12, A: 0xff},
+	NoGoZone:    color.NRGBA{R: 0xc0, G: 0x00, B: 0x00, A: 0x60},
+	NoMopZone:   color.NRGBA{R: 0x80, G: 0x00, B: 0xc0, A: 0x60},
+	VirtualWall: color.NRGBA{R: 0xc0, G: 0x00, B: 0x00, A: 0xff},
+	Segments: []color.NRGBA{
+		{R: 0x19, G: 0xa1, B: 0xa1, A: 0xff},
+		{R: 0x7a, G: 0xc0, B: 0x37, A: 0xff},
+		{R: 0xdf, G: 0x5a, B: 0x49, A: 0xff},
+		{R: 0xf7, G: 0xc8, B: 0x41, A: 0xff},
+		{R: 0x56, G: 0xaf, B: 0xfc, A: 0xff},
+		{R: 0x9b, G: 0x59, B: 0xb6, A: 0xff},
+	},
+}
+
+var DarkTheme = &Theme{
+	Background:  color.NRGBA{R: 0x12, G: 0x12, B: 0x12, A: 0xff},
+	Floor:       color.NRGBA{R: 0x3a, G: 0x3f, B: 0x44, A: 0xff},
+	Wall:        color.NRGBA{R: 0xd0, G: 0xd0, B: 0xd0, A: 0xff},
+	Path:        color.NRGBA{R: 0xa0, G: 0xa0, B: 0xa0, A: 0xff},
+	GoToPath:    color.NRGBA{R: 0x2e, G: 0xcc, B: 0x71, A: 0xff},
+	Charger:     color.NRGBA{R: 0x2e, G: 0xcc, B: 0x71, A: 0xff},
+	Robot:       color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+	Obstacle:    color.NRGBA{R: 0xf3, G: 0x9c, B: 0x12, A: 0xff},
+	NoGoZone:    color.NRGBA{R: 0xe7, G: 0x4c, B: 0x3c, A: 0x60},
+	NoMopZone:   color.NRGBA{R: 0xa5, G: 0x69, B: 0xbd, A: 0x60},
+	VirtualWall: color.NRGBA{R: 0xe7, G: 0x4c, B: 0x3c, A: 0xff},
+	Segments: []color.NRGBA{
+		{R: 0x1f, G: 0x4e, B: 0x5f, A: 0xff},
+		{R: 0x3d, G: 0x5a, B: 0x2a, A: 0xff},
+		{R: 0x6b, G: 0x2f, B: 0x2a, A: 0xff},
+		{R: 0x6e, G: 0x5a, B: 0x1f, A: 0xff},
+		{R: 0x2c, G: 0x4a, B: 0x6e, A: 0xff},
+		{R: 0x4a, G: 0x2c, B: 0x5a, A: 0xff},
+	},
+}
+
+// segmentColour returns the colour of a segment, segment 0 is floor which does not belong
+// to a segment.
+func (t *Theme) segmentColour(segment int) color.NRGBA {
+	if segment == 0 || len(t.Segments) == 0 {
+		return t.Floor
+	}
+
+	return t.Segments[(segment-1)%len(t.Segments)]
+}
@@ -0,0 +1,190 @@
+package maprender
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/l-ross/xiaomi/vacuum/rrmap"
+)
+
+// Render the map as an image.
+func Render(m *rrmap.Map, opts ...Option) (image.Image, error) {
+	c, o, err := newCanvas(m, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	t := o.Theme
+	dst := image.NewRGBA(image.Rectangle{Max: c.Size()})
+
+	fillRect(dst, dst.Bounds(), t.Background)
+
+	for y := c.Bounds.Min.Y; y < c.Bounds.Max.Y; y++ {
+		for x := c.Bounds.Min.X; x < c.Bounds.Max.X; x++ {
+			pt, segment := c.img.At(x, y)
+
+			switch pt {
+			case rrmap.PixelWall:
+				fillRect(dst, c.PixelRect(x, y), t.Wall)
+			case rrmap.PixelFloor:
+				fillRect(dst, c.PixelRect(x, y), t.segmentColour(segment))
+			}
+		}
+	}
+
+	for _, z := range m.ForbiddenZones {
+		fillPolygon(dst, c, z[:], t.NoGoZone)
+	}
+
+	for _, z := range m.ForbiddenMopZones {
+		fillPolygon(dst, c, z[:], t.NoMopZone)
+	}
+
+	lineWidth := math.Max(1, float64(c.Scale)/2)
+
+	for _, w := range m.VirtualWalls {
+		drawLine(dst, c, w.Start, w.End, lineWidth, t.VirtualWall)
+	}
+
+	if m.Path != nil {
+		drawPath(dst, c, m.Path.Points, lineWidth/2, t.Path)
+	}
+
+	if m.GoToPath != nil {
+		drawPath(dst, c, m.GoToPath.Points, lineWidth/2, t.GoToPath)
+	}
+
+	if m.GoToPredictedPath != nil {
+		drawPath(dst, c, m.GoToPredictedPath.Points, lineWidth/2, t.GoToPath)
+	}
+
+	for _, ob := range m.Obstacles {
+		x, y := c.ToPixel(ob.Point)
+		half := c.Length(obstacleSize) / 2
+		fillRect(dst, image.Rect(int(x-half), int(y-half), int(x+half), int(y+half)), t.Obstacle)
+	}
+
+	if m.Charger != nil {
+		fillCircle(dst, c, *m.Charger, c.Length(chargerRadius), t.Charger)
+	}
+
+	if m.Robot != nil {
+		fillCircle(dst, c, m.Robot.Point, c.Length(robotRadius), t.Robot)
+	}
+
+	return dst, nil
+}
+
+// EncodePNG renders the map and writes it to w as a PNG.
+func EncodePNG(w io.Writer, m *rrmap.Map, opts ...Option) error {
+	img, err := Render(m, opts...)
+	if err != nil {
+		return err
+	}
+
+	return png.Encode(w, img)
+}
+
+func fillRect(dst draw.Image, r image.Rectangle, c color.NRGBA) {
+	if c.A == 0 {
+		return
+	}
+
+	draw.Draw(dst, r, image.NewUniform(c), image.Point{}, draw.Over)
+}
+
+func drawPath(dst draw.Image, c *canvas, points []rrmap.Point, width float64, col color.NRGBA) {
+	for i := 1; i < len(points); i++ {
+		drawLine(dst, c, points[i-1], points[i], width, col)
+	}
+}
+
+// drawLine draws a line of the given width by stepping along it one output pixel at a time.
+func drawLine(dst draw.Image, c *canvas, from, to rrmap.Point, width float64, col color.NRGBA) {
+	x0, y0 := c.ToPixel(from)
+	x1, y1 := c.ToPixel(to)
+
+	steps := int(math.Ceil(math.Max(math.Abs(x1-x0), math.Abs(y1-y0))))
+	if steps == 0 {
+		steps = 1
+	}
+
+	half := width / 2
+	drawn := make(map[image.Point]bool)
+
+	for i := 0; i <= steps; i++ {
+		f := float64(i) / float64(steps)
+		x := x0 + (x1-x0)*f
+		y := y0 + (y1-y0)*f
+
+		r := image.Rect(int(math.Floor(x-half)), int(math.Floor(y-half)), int(math.Ceil(x+half)), int(math.Ceil(y+half)))
+
+		// Avoid blending the same pixels multiple times for translucent colours.
+		for py := r.Min.Y; py < r.Max.Y; py++ {
+			for px := r.Min.X; px < r.Max.X; px++ {
+				p := image.Pt(px, py)
+				if drawn[p] {
+					continue
+				}
+
+				drawn[p] = true
+				fillRect(dst, image.Rect(px, py, px+1, py+1), col)
+			}
+		}
+	}
+}
+
+// fillPolygon fills the polygon using the even-odd rule, sampling at the centre of each
+// output pixel.
+func fillPolygon(dst draw.Image, c *canvas, points []rrmap.Point, col color.NRGBA) {
+	xs := make([]float64, len(points))
+	ys := make([]float64, len(points))
+
+	minY, maxY := math.Inf(1), math.Inf(-1)
+
+	for i, p := range points {
+		xs[i], ys[i] = c.ToPixel(p)
+		minY = math.Min(minY, ys[i])
+		maxY = math.Max(maxY, ys[i])
+	}
+
+	for y := int(math.Floor(minY)); y < int(math.Ceil(maxY)); y++ {
+		sy := float64(y) + 0.5
+		crossings := make([]float64, 0)
+
+		for i := range points {
+			j := (i + 1) % len(points)
+			if (ys[i] <= sy) != (ys[j] <= sy) {
+				crossings = append(crossings, xs[i]+(sy-ys[i])/(ys[j]-ys[i])*(xs[j]-xs[i]))
+			}
+		}
+
+		sort.Float64s(crossings)
+
+		for i := 0; i+1 < len(crossings); i += 2 {
+			x0 := int(math.Round(crossings[i]))
+			x1 := int(math.Round(crossings[i+1]))
+			fillRect(dst, image.Rect(x0, y, x1, y+1), col)
+		}
+	}
+}
+
+func fillCircle(dst draw.Image, c *canvas, centre rrmap.Point, radius float64, col color.NRGBA) {
+	cx, cy := c.ToPixel(centre)
+
+	for y := int(math.Floor(cy - radius)); y <= int(math.Ceil(cy+radius)); y++ {
+		for x := int(math.Floor(cx - radius)); x <= int(math.Ceil(cx+radius)); x++ {
+			dx := float64(x) + 0.5 - cx
+			dy := float64(y) + 0.5 - cy
+
+			if dx*dx+dy*dy <= radius*radius {
+				fillRect(dst, image.Rect(x, y, x+1, y+1), col)
+			}
+		}
+	}
+}
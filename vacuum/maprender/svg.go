@@ -0,0 +1,144 @@
+package maprender
+
+import (
+	"bufio"
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+
+	"github.com/l-ross/xiaomi/vacuum/rrmap"
+)
+
+// EncodeSVG renders the map and writes it to w as an SVG.
+func EncodeSVG(w io.Writer, m *rrmap.Map, opts ...Option) error {
+	c, o, err := newCanvas(m, opts)
+	if err != nil {
+		return err
+	}
+
+	t := o.Theme
+	size := c.Size()
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`+"\n",
+		size.X, size.Y, size.X, size.Y)
+
+	if t.Background.A > 0 {
+		fmt.Fprintf(bw, `<rect width="%d" height="%d" %s/>`+"\n", size.X, size.Y, fill(t.Background))
+	}
+
+	// Merge consecutive pixels of the same colour on each row to keep the output small.
+	for y := c.Bounds.Min.Y; y < c.Bounds.Max.Y; y++ {
+		for x := c.Bounds.Min.X; x < c.Bounds.Max.X; {
+			col, ok := pixelColour(c.img, t, x, y)
+
+			end := x + 1
+			for end < c.Bounds.Max.X {
+				next, nextOK := pixelColour(c.img, t, end, y)
+				if next != col || nextOK != ok {
+					break
+				}
+				end++
+			}
+
+			if ok {
+				r := c.PixelRect(x, y)
+				fmt.Fprintf(bw, `<rect x="%d" y="%d" width="%d" height="%d" %s/>`+"\n",
+					r.Min.X, r.Min.Y, (end-x)*c.Scale, c.Scale, fill(col))
+			}
+
+			x = end
+		}
+	}
+
+	for _, z := range m.ForbiddenZones {
+		fmt.Fprintf(bw, `<polygon points="%s" %s/>`+"\n", points(c, z[:]), fill(t.NoGoZone))
+	}
+
+	for _, z := range m.ForbiddenMopZones {
+		fmt.Fprintf(bw, `<polygon points="%s" %s/>`+"\n", points(c, z[:]), fill(t.NoMopZone))
+	}
+
+	lineWidth := float64(c.Scale) / 2
+
+	for _, wall := range m.VirtualWalls {
+		x0, y0 := c.ToPixel(wall.Start)
+		x1, y1 := c.ToPixel(wall.End)
+		fmt.Fprintf(bw, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke-width="%.1f" %s/>`+"\n",
+			x0, y0, x1, y1, lineWidth, stroke(t.VirtualWall))
+	}
+
+	if m.Path != nil {
+		fmt.Fprintf(bw, `<polyline points="%s" fill="none" stroke-width="%.1f" %s/>`+"\n",
+			points(c, m.Path.Points), lineWidth/2, stroke(t.Path))
+	}
+
+	if m.GoToPath != nil {
+		fmt.Fprintf(bw, `<polyline points="%s" fill="none" stroke-width="%.1f" %s/>`+"\n",
+			points(c, m.GoToPath.Points), lineWidth/2, stroke(t.GoToPath))
+	}
+
+	// The predicted path is dashed to distinguish it from the path travelled.
+	if m.GoToPredictedPath != nil {
+		fmt.Fprintf(bw, `<polyline points="%s" fill="none" stroke-width="%.1f" stroke-dasharray="%d" %s/>`+"\n",
+			points(c, m.GoToPredictedPath.Points), lineWidth/2, c.Scale, stroke(t.GoToPath))
+	}
+
+	for _, ob := range m.Obstacles {
+		x, y := c.ToPixel(ob.Point)
+		s := c.Length(obstacleSize)
+		fmt.Fprintf(bw, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" %s/>`+"\n", x-s/2, y-s/2, s, s, fill(t.Obstacle))
+	}
+
+	if m.Charger != nil {
+		x, y := c.ToPixel(*m.Charger)
+		fmt.Fprintf(bw, `<circle cx="%.1f" cy="%.1f" r="%.1f" %s/>`+"\n", x, y, c.Length(chargerRadius), fill(t.Charger))
+	}
+
+	if m.Robot != nil {
+		x, y := c.ToPixel(m.Robot.Point)
+		fmt.Fprintf(bw, `<circle cx="%.1f" cy="%.1f" r="%.1f" %s/>`+"\n", x, y, c.Length(robotRadius), fill(t.Robot))
+	}
+
+	fmt.Fprintln(bw, `</svg>`)
+
+	return bw.Flush()
+}
+
+// pixelColour returns the colour of the map pixel and whether it should be drawn.
+func pixelColour(img *rrmap.Image, t *Theme, x, y int) (color.NRGBA, bool) {
+	pt, segment := img.At(x, y)
+
+	switch pt {
+	case rrmap.PixelWall:
+		return t.Wall, t.Wall.A > 0
+	case rrmap.PixelFloor:
+		col := t.segmentColour(segment)
+		return col, col.A > 0
+	}
+
+	return color.NRGBA{}, false
+}
+
+func points(c *canvas, pts []rrmap.Point) string {
+	s := make([]string, len(pts))
+	for i, p := range pts {
+		x, y := c.ToPixel(p)
+		s[i] = fmt.Sprintf("%.1f,%.1f", x, y)
+	}
+
+	return strings.Join(s, " ")
+}
+
+func hex(c color.NRGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func fill(c color.NRGBA) string {
+	return fmt.Sprintf(`fill="%s" fill-opacity="%.2f"`, hex(c), float64(c.A)/0xff)
+}
+
+func stroke(c color.NRGBA) string {
+	return fmt.Sprintf(`stroke="%s" stroke-opacity="%.2f"`, hex(c), float64(c.A)/0xff)
+}
@@ -0,0 +1,364 @@
+package vacuum
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Model describes what a vacuum model supports, so that only the appropriate controls are
+// offered. Models are looked up by the model reported by Info.
+type Model struct {
+	// ID is the model reported by Info, e.g. roborock.vacuum.s5.
+	ID   string
+	Name string
+
+	// FanSpeeds maps each supported FanSpeed to the fan power sent to the vacuum. The fan
+	// powers of the v1 also depend on its firmware, see Vacuum.SupportedFanSpeeds.
+	FanSpeeds FanSpeedTable
+	// WaterFlows and MopModes are empty if the model cannot control mopping.
+	WaterFlows []WaterFlow
+	MopModes   []MopMode
+	// ConsumableLifetimes are the replacement intervals of the timed consumables of the model.
+	ConsumableLifetimes map[ConsumableKind]time.Duration
+	// ConsumableLimits are the number of uses of the counted consumables of the model, it is
+	// empty if the model has no dock which needs maintenance.
+	ConsumableLimits map[ConsumableKind]int
+	// DockTypes are the docks the model can be paired with.
+	DockTypes []DockType
+	// Methods are the optional methods supported by the model, see Supports.
+	Methods []string
+	// MapFeatures are the map features, such as segment cleaning and multiple floors, the model
+	// supports. Whether they are enabled depends on the firmware, see Vacuum.Features, these
+	// are only consulted if the firmware cannot report its features.
+	MapFeatures FeatureSet
+}
+
+// Supports reports whether the model supports the method. Methods which are supported by all
+// models are always reported as supported.
+func (m *Model) Supports(method string) bool {
+	if !containsString(optionalMethods, method) {
+		return true
+	}
+
+	return containsString(m.Methods, method)
+}
+
+// SupportedFanSpeeds returns the fan speeds supported by the model, in order.
+func (m *Model) SupportedFanSpeeds() []FanSpeed {
+	return m.FanSpeeds.speeds()
+}
+
+// SupportsWaterFlow reports whether the model supports the water flow.
+func (m *Model) SupportsWaterFlow(flow WaterFlow) bool {
+	for _, w := range m.WaterFlows {
+		if w == flow {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SupportsMopMode reports whether the model supports the mop mode.
+func (m *Model) SupportsMopMode(mode MopMode) bool {
+	for _, w := range m.MopModes {
+		if w == mode {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsString(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+func concatStrings(s ...[]string) []string {
+	ret := make([]string, 0)
+	for _, e := range s {
+		ret = append(ret, e...)
+	}
+
+	return ret
+}
+
+// The methods which are only supported by some models.
+var (
+	waterBoxMethods        = []string{"get_water_box_custom_mode", "set_water_box_custom_mode"}
+	mopModeMethods         = []string{"get_mop_mode", "set_mop_mode"}
+	carpetCleanModeMethods = []string{"get_carpet_clean_mode", "set_carpet_clean_mode"}
+	dustCollectionMethods  = []string{
+		"app_start_collect_dust", "app_stop_collect_dust",
+		"get_dust_collection_mode", "set_dust_collection_mode",
+	}
+	washMethods = []string{
+		"app_start_wash", "app_stop_wash",
+		"get_wash_towel_mode", "set_wash_towel_mode",
+		"get_dryer_setting", "app_set_dryer_setting",
+	}
+
+	optionalMethods = concatStrings(
+		waterBoxMethods, mopModeMethods, carpetCleanModeMethods, dustCollectionMethods, washMethods,
+	)
+)
+
+// dockConsumables returns the recommended limits of the counted consumables.
+func dockConsumables(kinds ...ConsumableKind) map[ConsumableKind]int {
+	ret := make(map[ConsumableKind]int, len(kinds))
+	for _, k := range kinds {
+		ret[k] = consumableLimits[k]
+	}
+
+	return ret
+}
+
+// The map features which are only supported by some models.
+var (
+	segmentFeatures    = []FeatureCode{FeatureCodeAutoSplitSegments, FeatureCodeOrderSegmentClean, FeatureCodeMapSegment}
+	multiFloorFeatures = []FeatureCode{FeatureCodeDeleteMap, FeatureCodeMultiFloorSupported}
+	allMapFeatures     = FeatureSet(concatFeatures(segmentFeatures, multiFloorFeatures))
+)
+
+func concatFeatures(f ...[]FeatureCode) []FeatureCode {
+	ret := make([]FeatureCode, 0)
+	for _, e := range f {
+		ret = append(ret, e...)
+	}
+
+	return ret
+}
+
+var (
+	allWaterFlows = []WaterFlow{WaterFlowOff, WaterFlowLow, WaterFlowMedium, WaterFlowHigh, WaterFlowCustom}
+	allMopModes   = []MopMode{MopModeStandard, MopModeDeep, MopModeDeepPlus, MopModeCustom}
+	allDockTypes  = []DockType{
+		DockTypeCharging, DockTypeAutoEmpty, DockTypeEmptyWashFill, DockTypeAutoEmptyPure,
+		DockTypeS7MaxVUltra, DockTypeS8Ultra, DockTypeS8MaxVUltra,
+	}
+)
+
+// DefaultModel is used for models which are not in Models. It supports everything, leaving
+// the vacuum to reject what it does not support.
+var DefaultModel = &Model{
+	ID:                  "unknown",
+	Name:                "Unknown",
+	FanSpeeds:           fanSpeedsDefault,
+	WaterFlows:          allWaterFlows,
+	MopModes:            allMopModes,
+	ConsumableLifetimes: ConsumableLifetimes(),
+	ConsumableLimits:    ConsumableLimits(),
+	DockTypes:           allDockTypes,
+	Methods:             optionalMethods,
+	MapFeatures:         allMapFeatures,
+}
+
+// Models are the known models, keyed by ID. Further models may be added before the package
+// is used.
+//
+// The capabilities follow the model definitions of python-miio and the Roborock product
+// specifications, fan speeds are described in fan.go. Where a capability could not be
+// confirmed it is left out, the model can be replaced in Models if it is supported.
+var Models = map[string]*Model{
+	"rockrobo.vacuum.v1": {
+		ID:                  "rockrobo.vacuum.v1",
+		Name:                "Mi Robot Vacuum",
+		FanSpeeds:           fanSpeedsV1,
+		ConsumableLifetimes: ConsumableLifetimes(),
+		DockTypes:           []DockType{DockTypeCharging},
+	},
+	"roborock.vacuum.s5": {
+		ID:                  "roborock.vacuum.s5",
+		Name:                "Roborock S5",
+		FanSpeeds:           fanSpeedsDefault,
+		ConsumableLifetimes: ConsumableLifetimes(),
+		DockTypes:           []DockType{DockTypeCharging},
+		MapFeatures:         segmentFeatures,
+	},
+	"roborock.vacuum.s5e": {
+		ID:                  "roborock.vacuum.s5e",
+		Name:                "Roborock S5 Max",
+		FanSpeeds:           fanSpeedsDefault,
+		WaterFlows:          []WaterFlow{WaterFlowOff, WaterFlowLow, WaterFlowMedium, WaterFlowHigh},
+		ConsumableLifetimes: ConsumableLifetimes(),
+		DockTypes:           []DockType{DockTypeCharging},
+		Methods:             waterBoxMethods,
+		MapFeatures:         allMapFeatures,
+	},
+	"roborock.vacuum.s6": {
+		ID:                  "roborock.vacuum.s6",
+		Name:                "Roborock S6",
+		FanSpeeds:           fanSpeedsDefault,
+		ConsumableLifetimes: ConsumableLifetimes(),
+		DockTypes:           []DockType{DockTypeCharging},
+		MapFeatures:         allMapFeatures,
+	},
+	"roborock.vacuum.a10": {
+		ID:                  "roborock.vacuum.a10",
+		Name:                "Roborock S6 MaxV",
+		FanSpeeds:           fanSpeedsDefault,
+		WaterFlows:          []WaterFlow{WaterFlowOff, WaterFlowLow, WaterFlowMedium, WaterFlowHigh},
+		ConsumableLifetimes: ConsumableLifetimes(),
+		DockTypes:           []DockType{DockTypeCharging},
+		Methods:             waterBoxMethods,
+		MapFeatures:         allMapFeatures,
+	},
+	"roborock.vacuum.a15": {
+		ID:         "roborock.vacuum.a15",
+		Name:       "Roborock S7",
+		FanSpeeds:  fanSpeedsS7,
+		WaterFlows: allWaterFlows,
+		// Deep+ is advertised from the S7 MaxV onwards, it could not be confirmed for the S7.
+		MopModes:            []MopMode{MopModeStandard, MopModeDeep, MopModeCustom},
+		ConsumableLifetimes: ConsumableLifetimes(),
+		ConsumableLimits:    dockConsumables(ConsumableDustBag),
+		DockTypes:           []DockType{DockTypeCharging, DockTypeAutoEmpty},
+		Methods:             concatStrings(waterBoxMethods, mopModeMethods, carpetCleanModeMethods, dustCollectionMethods),
+		MapFeatures:         allMapFeatures,
+	},
+	"roborock.vacuum.a27": {
+		ID:                  "roborock.vacuum.a27",
+		Name:                "Roborock S7 MaxV",
+		FanSpeeds:           fanSpeedsS7MaxV,
+		WaterFlows:          allWaterFlows,
+		MopModes:            allMopModes,
+		ConsumableLifetimes: ConsumableLifetimes(),
+		ConsumableLimits:    ConsumableLimits(),
+		DockTypes:           allDockTypes,
+		Methods:             optionalMethods,
+		MapFeatures:         allMapFeatures,
+	},
+	"roborock.vacuum.a62": {
+		ID:                  "roborock.vacuum.a62",
+		Name:                "Roborock S7 Pro Ultra",
+		FanSpeeds:           fanSpeedsS7MaxV,
+		WaterFlows:          allWaterFlows,
+		MopModes:            allMopModes,
+		ConsumableLifetimes: ConsumableLifetimes(),
+		ConsumableLimits:    ConsumableLimits(),
+		DockTypes:           allDockTypes,
+		Methods:             optionalMethods,
+		MapFeatures:         allMapFeatures,
+	},
+	"roborock.vacuum.a38": {
+		ID:                  "roborock.vacuum.a38",
+		Name:                "Roborock Q7 Max",
+		FanSpeeds:           fanSpeedsDefault,
+		WaterFlows:          []WaterFlow{WaterFlowOff, WaterFlowLow, WaterFlowMedium, WaterFlowHigh},
+		ConsumableLifetimes: ConsumableLifetimes(),
+		ConsumableLimits:    dockConsumables(ConsumableDustBag),
+		DockTypes:           []DockType{DockTypeCharging, DockTypeAutoEmptyPure},
+		Methods:             concatStrings(waterBoxMethods, dustCollectionMethods),
+		MapFeatures:         allMapFeatures,
+	},
+	"roborock.vacuum.a40": {
+		ID:                  "roborock.vacuum.a40",
+		Name:                "Roborock Q7",
+		FanSpeeds:           fanSpeedsDefault,
+		WaterFlows:          []WaterFlow{WaterFlowOff, WaterFlowLow, WaterFlowMedium, WaterFlowHigh},
+		ConsumableLifetimes: ConsumableLifetimes(),
+		ConsumableLimits:    dockConsumables(ConsumableDustBag),
+		DockTypes:           []DockType{DockTypeCharging, DockTypeAutoEmptyPure},
+		Methods:             concatStrings(waterBoxMethods, dustCollectionMethods),
+		MapFeatures:         allMapFeatures,
+	},
+	"roborock.vacuum.a51": {
+		ID:                  "roborock.vacuum.a51",
+		Name:                "Roborock S8",
+		FanSpeeds:           fanSpeedsS7MaxV,
+		WaterFlows:          allWaterFlows,
+		MopModes:            allMopModes,
+		ConsumableLifetimes: ConsumableLifetimes(),
+		ConsumableLimits:    dockConsumables(ConsumableDustBag),
+		DockTypes:           []DockType{DockTypeCharging, DockTypeAutoEmptyPure},
+		Methods:             concatStrings(waterBoxMethods, mopModeMethods, carpetCleanModeMethods, dustCollectionMethods),
+		MapFeatures:         allMapFeatures,
+	},
+	"roborock.vacuum.a70": {
+		ID:                  "roborock.vacuum.a70",
+		Name:                "Roborock S8 Pro Ultra",
+		FanSpeeds:           fanSpeedsS7MaxV,
+		WaterFlows:          allWaterFlows,
+		MopModes:            allMopModes,
+		ConsumableLifetimes: ConsumableLifetimes(),
+		ConsumableLimits:    ConsumableLimits(),
+		DockTypes:           []DockType{DockTypeCharging, DockTypeS8Ultra},
+		Methods:             optionalMethods,
+		MapFeatures:         allMapFeatures,
+	},
+}
+
+// LookupModel returns the Model with the ID, or DefaultModel if it is not known.
+func LookupModel(id string) *Model {
+	if m, ok := Models[id]; ok {
+		return m
+	}
+
+	return DefaultModel
+}
+
+// Model returns the Model of the Vacuum.
+func (v *Vacuum) Model() (*Model, error) {
+	id, err := v.model()
+	if err != nil {
+		return nil, err
+	}
+
+	return LookupModel(id), nil
+}
+
+// modelOrDefault returns the Model of the Vacuum, or DefaultModel if it cannot be retrieved.
+func (v *Vacuum) modelOrDefault() *Model {
+	m, err := v.Model()
+	if err != nil {
+		return DefaultModel
+	}
+
+	return m
+}
+
+// requireMethod returns ErrNotSupported if the method is not supported by the Vacuum's model.
+// If the model cannot be retrieved the method is not gated, the vacuum rejects it with
+// ErrNotSupported if it is not supported.
+func (v *Vacuum) requireMethod(method string) error {
+	m, err := v.Model()
+	if err != nil {
+		return nil
+	}
+
+	if !m.Supports(method) {
+		return fmt.Errorf("%w: %s on %s", ErrNotSupported, method, m.Name)
+	}
+
+	return nil
+}
+
+// requireMapFeatures returns ErrNotSupported if any of the map features are not enabled on the
+// Vacuum. Firmware which cannot report its features is gated on the map features of its model
+// instead, and is not gated if the model cannot be retrieved either.
+func (v *Vacuum) requireMapFeatures(codes ...FeatureCode) error {
+	_, err := v.Features()
+	if !errors.Is(err, ErrNotSupported) {
+		return v.requireFeatures(codes...)
+	}
+
+	m, err := v.Model()
+	if err != nil {
+		return nil
+	}
+
+	for _, c := range codes {
+		if !m.MapFeatures.Has(c) {
+			return fmt.Errorf("%w: map feature %s on %s", ErrNotSupported, c, m.Name)
+		}
+	}
+
+	return nil
+}
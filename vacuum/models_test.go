@@ -0,0 +1,239 @@
+package vacuum
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupModel(t *testing.T) {
+	t.Parallel()
+
+	s5 := LookupModel("roborock.vacuum.s5")
+	assert.Equal(t, "Roborock S5", s5.Name)
+	assert.Equal(t, []FanSpeed{
+		FanSpeedGentle, FanSpeedQuiet, FanSpeedBalanced, FanSpeedTurbo, FanSpeedMax, FanSpeedAuto,
+	}, s5.SupportedFanSpeeds())
+	assert.True(t, s5.Supports("app_start"))
+	assert.False(t, s5.Supports("set_mop_mode"))
+	assert.False(t, s5.SupportsWaterFlow(WaterFlowLow))
+
+	unknown := LookupModel("roborock.vacuum.zz")
+	assert.Same(t, DefaultModel, unknown)
+	assert.True(t, unknown.Supports("app_start_wash"))
+}
+
+func TestModels(t *testing.T) {
+	t.Parallel()
+
+	for id, m := range Models {
+		assert.Equal(t, id, m.ID)
+		assert.NotEmpty(t, m.FanSpeeds, id)
+		assert.NotEmpty(t, m.ConsumableLifetimes, id)
+
+		for _, method := range m.Methods {
+			assert.Contains(t, optionalMethods, method, id)
+		}
+	}
+}
+
+func TestVacuum_Model(t *testing.T) {
+	t.Parallel()
+
+	c := &mockClient{
+		rsps: map[string][][]byte{
+			"miIO.info": {[]byte(`{"result": {"model": "roborock.vacuum.a15"}, "id": 1}`)},
+		},
+	}
+	v := &Vacuum{client: c}
+
+	got, err := v.Model()
+	require.NoError(t, err)
+	assert.Equal(t, "Roborock S7", got.Name)
+
+	// The model is cached.
+	_, err = v.Model()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"miIO.info"}, c.methods())
+}
+
+func TestVacuum_Model_NotSupported(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		model string
+		call  func(v *Vacuum) error
+	}{
+		"water flow": {
+			model: "roborock.vacuum.s5",
+			call:  func(v *Vacuum) error { return v.SetWaterBoxCustomMode(WaterFlowHigh) },
+		},
+		"deep plus mop mode": {
+			model: "roborock.vacuum.a15",
+			call:  func(v *Vacuum) error { return v.SetMopMode(MopModeDeepPlus) },
+		},
+		"carpet clean mode": {
+			model: "roborock.vacuum.s6",
+			call:  func(v *Vacuum) error { return v.SetCarpetCleanMode(CarpetCleanModeAvoid) },
+		},
+		"wash": {
+			model: "roborock.vacuum.a15",
+			call:  func(v *Vacuum) error { return v.StartWash() },
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &mockClient{}
+			v := &Vacuum{client: c, modelName: tt.model}
+
+			err := tt.call(v)
+			assert.True(t, errors.Is(err, ErrNotSupported))
+			assert.Empty(t, c.reqs)
+		})
+	}
+}
+
+func TestVacuum_GetConsumables_ModelLifetimes(t *testing.T) {
+	t.Parallel()
+
+	v := &Vacuum{
+		modelName: "rockrobo.vacuum.v1",
+		client: &mockClient{
+			rsp: []byte(`{"result": [{"main_brush_work_time": 0}], "id": 1}`),
+		},
+	}
+
+	got, err := v.GetConsumables()
+	require.NoError(t, err)
+	assert.Equal(t, 100, got.RemainingPercent(ConsumableMainBrush))
+	assert.Equal(t, 0, got.RemainingPercent(ConsumableDustBag))
+}
+
+func TestVacuum_GetConsumables_NoModel(t *testing.T) {
+	t.Parallel()
+
+	c := &mockClient{
+		rsps: map[string][][]byte{
+			"miIO.info":      {[]byte(`{"error": {"code": -1, "message": "timeout"}, "id": 1}`)},
+			"get_consumable": {[]byte(`{"result": [{"filter_work_time": 0}], "id": 1}`)},
+		},
+	}
+	v := &Vacuum{client: c}
+
+	got, err := v.GetConsumables()
+	require.NoError(t, err)
+	assert.Equal(t, 100, got.RemainingPercent(ConsumableDustBag))
+}
+
+func TestVacuum_Dock_ModelDockTypes(t *testing.T) {
+	t.Parallel()
+
+	c := &mockClient{}
+	v := &Vacuum{client: c, modelName: "roborock.vacuum.s5"}
+
+	// The S5 cannot be paired with an auto-empty dock, so the dock is not queried.
+	assert.True(t, errors.Is(v.StartCollectDust(), ErrNotSupported))
+	_, err := v.GetDustCollectionMode()
+	assert.True(t, errors.Is(err, ErrNotSupported))
+	assert.Empty(t, c.reqs)
+}
+
+func TestVacuum_MapFeatures_NoFeatures(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		model   string
+		methods []string
+		wantErr error
+	}{
+		"v1": {
+			model:   "rockrobo.vacuum.v1",
+			methods: []string{"get_fw_features"},
+			wantErr: ErrNotSupported,
+		},
+		"s5": {
+			model:   "roborock.vacuum.s5",
+			methods: []string{"get_fw_features", "app_segment_clean"},
+		},
+		"q7": {
+			model:   "roborock.vacuum.a40",
+			methods: []string{"get_fw_features", "app_segment_clean"},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &mockClient{
+				rsps: map[string][][]byte{
+					"get_fw_features": {[]byte(`{"error": {"code": -32601, "message": "Method not found."}, "id": 1}`)},
+				},
+			}
+			v := &Vacuum{client: c, modelName: tt.model}
+
+			err := v.SegmentClean([]int{16}, 1)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.methods, c.methods())
+		})
+	}
+
+	// The S5 has a single map.
+	c := &mockClient{
+		rsps: map[string][][]byte{
+			"get_fw_features": {[]byte(`{"error": {"code": -32601, "message": "Method not found."}, "id": 1}`)},
+		},
+	}
+	v := &Vacuum{client: c, modelName: "roborock.vacuum.s5"}
+
+	_, err := v.ListMaps()
+	assert.True(t, errors.Is(err, ErrNotSupported))
+}
+
+func TestLookupModel_S8(t *testing.T) {
+	t.Parallel()
+
+	m := LookupModel("roborock.vacuum.a70")
+	assert.Equal(t, "Roborock S8 Pro Ultra", m.Name)
+	assert.True(t, m.Supports("app_start_wash"))
+	assert.True(t, m.MapFeatures.Has(FeatureCodeMapSegment, FeatureCodeMultiFloorSupported))
+	assert.Contains(t, m.SupportedFanSpeeds(), FanSpeedMaxPlus)
+	assert.Contains(t, m.DockTypes, DockTypeS8Ultra)
+}
+
+func TestVacuum_NoModel(t *testing.T) {
+	t.Parallel()
+
+	c := &mockClient{
+		rsps: map[string][][]byte{
+			"miIO.info":       {[]byte(`{"error": {"code": -1, "message": "timeout"}, "id": 1}`)},
+			"get_custom_mode": {[]byte(`{"result": [104], "id": 1}`)},
+		},
+	}
+	v := &Vacuum{client: c}
+
+	// The DefaultModel is used if the model cannot be retrieved.
+	assert.Equal(t, DefaultModel.SupportedFanSpeeds(), v.SupportedFanSpeeds())
+
+	got, err := v.GetFanSpeed()
+	require.NoError(t, err)
+	assert.Equal(t, FanSpeedMax, got)
+
+	require.NoError(t, v.SetFanSpeed(FanSpeedTurbo))
+	require.NoError(t, v.SetWaterBoxCustomMode(WaterFlowHigh))
+	require.NoError(t, v.SetMopMode(MopModeDeep))
+	assert.Contains(t, c.methods(), "set_custom_mode")
+	assert.Contains(t, c.methods(), "set_water_box_custom_mode")
+	assert.Contains(t, c.methods(), "set_mop_mode")
+}
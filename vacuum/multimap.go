@@ -0,0 +1,165 @@
+package vacuum
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type MapList struct {
+	// MaxMaps is the maximum number of maps (floors) which can be saved.
+	MaxMaps int
+	// MaxBackups is the maximum number of backups saved for each map.
+	MaxBackups int
+	Maps       []MapInfo
+}
+
+type MapInfo struct {
+	// Flag identifies the map when loading it.
+	Flag    int
+	Name    string
+	Added   time.Time
+	Backups []MapBackup
+}
+
+type MapBackup struct {
+	// Flag identifies the backup when recovering it.
+	Flag  int
+	Added time.Time
+}
+
+// ListMaps retrieves the maps (floors) saved on the Vacuum.
+func (v *Vacuum) ListMaps() (*MapList, error) {
+	err := v.requireMapFeatures(FeatureCodeMultiFloorSupported)
+	if err != nil {
+		return nil, err
+	}
+
+	type backup struct {
+		Flag    int   `json:"mapFlag"`
+		AddTime int64 `json:"add_time"`
+	}
+
+	type mapList struct {
+		MaxMultiMap int `json:"max_multi_map"`
+		MaxBakMap   int `json:"max_bak_map"`
+		MapInfo     []struct {
+			Flag    int      `json:"mapFlag"`
+			AddTime int64    `json:"add_time"`
+			Name    string   `json:"name"`
+			BakMaps []backup `json:"bak_maps"`
+		} `json:"map_info"`
+	}
+
+	rsp := make([]mapList, 0)
+
+	err = v.do("get_multi_maps_list", nil, &rsp)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rsp) != 1 {
+		return nil, ErrUnexpectedResponse
+	}
+
+	l := &MapList{
+		MaxMaps:    rsp[0].MaxMultiMap,
+		MaxBackups: rsp[0].MaxBakMap,
+		Maps:       make([]MapInfo, len(rsp[0].MapInfo)),
+	}
+
+	for i, m := range rsp[0].MapInfo {
+		l.Maps[i] = MapInfo{
+			Flag:    m.Flag,
+			Name:    m.Name,
+			Added:   time.Unix(m.AddTime, 0),
+			Backups: make([]MapBackup, len(m.BakMaps)),
+		}
+
+		for j, b := range m.BakMaps {
+			l.Maps[i].Backups[j] = MapBackup{
+				Flag:  b.Flag,
+				Added: time.Unix(b.AddTime, 0),
+			}
+		}
+	}
+
+	return l, nil
+}
+
+// LoadMap loads the map (floor) with the provided flag, see ListMaps.
+func (v *Vacuum) LoadMap(flag int) error {
+	err := v.requireMapFeatures(FeatureCodeMultiFloorSupported)
+	if err != nil {
+		return err
+	}
+
+	p, err := json.Marshal([]int{flag})
+	if err != nil {
+		return err
+	}
+
+	return v.doParams("load_multi_map", p)
+}
+
+// GetRecoverMaps retrieves the backups of the current map which can be recovered.
+func (v *Vacuum) GetRecoverMaps() ([]MapBackup, error) {
+	err := v.requireMapFeatures(FeatureCodeMultiFloorSupported)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each backup is of the form [flag, add_time].
+	rsp := make([][]int64, 0)
+
+	err = v.do("get_recover_maps", nil, &rsp)
+	if err != nil {
+		return nil, err
+	}
+
+	backups := make([]MapBackup, len(rsp))
+
+	for i, b := range rsp {
+		if len(b) < 2 {
+			return nil, ErrUnexpectedResponse
+		}
+
+		backups[i] = MapBackup{
+			Flag:  int(b[0]),
+			Added: time.Unix(b[1], 0),
+		}
+	}
+
+	return backups, nil
+}
+
+// RecoverMap restores the backup of the current map with the provided flag, see GetRecoverMaps.
+func (v *Vacuum) RecoverMap(flag int) error {
+	err := v.requireMapFeatures(FeatureCodeMultiFloorSupported)
+	if err != nil {
+		return err
+	}
+
+	p, err := json.Marshal([]int{flag})
+	if err != nil {
+		return err
+	}
+
+	return v.doParams("recover_map", p)
+}
+
+// ResetMap deletes the current map. Unlike the other methods in this file it is not gated on
+// FeatureCodeMultiFloorSupported, as it is also supported by firmware with a single map, as are
+// UseNewMap and UseOldMap.
+func (v *Vacuum) ResetMap() error {
+	return v.doSimple("reset_map")
+}
+
+// UseNewMap accepts the map generated by the last clean, replacing the saved map.
+func (v *Vacuum) UseNewMap() error {
+	return v.doSimple("use_new_map")
+}
+
+// UseOldMap discards the map generated by the last clean, keeping the saved map.
+func (v *Vacuum) UseOldMap() error {
+	return v.doSimple("use_old_map")
+}
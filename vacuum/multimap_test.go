@@ -0,0 +1,126 @@
+package vacuum
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testMultiFloorFeatures = []byte(`
+	{
+		"result": [111, 112, 113, 114, 115, 116, 117, 118, 119, 120, 122, 125],
+		"id": 1
+	}`,
+)
+
+func TestVacuum_ListMaps(t *testing.T) {
+	t.Parallel()
+
+	v := &Vacuum{
+		client: &mockClient{
+			rsps: map[string][][]byte{
+				"get_fw_features": {testMultiFloorFeatures},
+				"get_multi_maps_list": {[]byte(`
+					{
+						"result": [{
+								"max_multi_map": 4,
+								"max_bak_map": 1,
+								"multi_map_count": 2,
+								"map_info": [{
+										"mapFlag": 0,
+										"add_time": 1614164329,
+										"length": 6,
+										"name": "Ground",
+										"bak_maps": [{"mapFlag": 4, "add_time": 1614160000}]
+									}, {
+										"mapFlag": 1,
+										"add_time": 1614170000,
+										"length": 5,
+										"name": "First",
+										"bak_maps": []
+									}
+								]
+							}
+						],
+						"id": 1
+					}`,
+				)},
+			},
+		},
+	}
+
+	got, err := v.ListMaps()
+	require.NoError(t, err)
+	assert.Equal(t, &MapList{
+		MaxMaps:    4,
+		MaxBackups: 1,
+		Maps: []MapInfo{
+			{
+				Flag:    0,
+				Name:    "Ground",
+				Added:   time.Unix(1614164329, 0),
+				Backups: []MapBackup{{Flag: 4, Added: time.Unix(1614160000, 0)}},
+			},
+			{
+				Flag:    1,
+				Name:    "First",
+				Added:   time.Unix(1614170000, 0),
+				Backups: []MapBackup{},
+			},
+		},
+	}, got)
+}
+
+func TestVacuum_LoadMap(t *testing.T) {
+	t.Parallel()
+
+	c := &mockClient{
+		rsps: map[string][][]byte{
+			"get_fw_features": {testMultiFloorFeatures},
+		},
+	}
+	v := &Vacuum{client: c}
+
+	err := v.LoadMap(1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"get_fw_features", "load_multi_map"}, c.methods())
+	assert.JSONEq(t, `[1]`, string(c.reqs[1].Params))
+}
+
+func TestVacuum_LoadMap_NotSupported(t *testing.T) {
+	t.Parallel()
+
+	v := &Vacuum{
+		client: &mockClient{
+			rsps: map[string][][]byte{
+				"get_fw_features": {testFeatures},
+			},
+		},
+	}
+
+	err := v.LoadMap(1)
+	require.True(t, errors.Is(err, ErrNotSupported))
+}
+
+func TestVacuum_GetRecoverMaps(t *testing.T) {
+	t.Parallel()
+
+	v := &Vacuum{
+		client: &mockClient{
+			rsps: map[string][][]byte{
+				"get_fw_features":  {testMultiFloorFeatures},
+				"get_recover_maps": {[]byte(`{"result": [[4, 1614160000], [5, 1614150000]], "id": 1}`)},
+			},
+		},
+	}
+
+	got, err := v.GetRecoverMaps()
+	require.NoError(t, err)
+	assert.Equal(t, []MapBackup{
+		{Flag: 4, Added: time.Unix(1614160000, 0)},
+		{Flag: 5, Added: time.Unix(1614150000, 0)},
+	}, got)
+}
@@ -2,11 +2,25 @@ package vacuum
 
 import "encoding/json"
 
+// RemoteStart enters remote control mode. ErrNotSupported is returned if the Remote feature
+// is not enabled.
 func (v *Vacuum) RemoteStart() error {
+	err := v.requireFeatures(FeatureCodeRemote)
+	if err != nil {
+		return err
+	}
+
 	return v.doSimple("app_rc_start")
 }
 
+// RemoteEnd leaves remote control mode. ErrNotSupported is returned if the Remote feature is
+// not enabled.
 func (v *Vacuum) RemoteEnd() error {
+	err := v.requireFeatures(FeatureCodeRemote)
+	if err != nil {
+		return err
+	}
+
 	return v.doSimple("app_rc_end")
 }
 
@@ -23,8 +37,14 @@ type RemoteMoveParams struct {
 
 // RemoteMove sends the provided move commands.
 // RemoteStart must be called first and the vacuums should be in the ManualMode state.
-// The vacuums state can be checked via the Status method.
+// The vacuums state can be checked via the Status method. ErrNotSupported is returned if the
+// Remote feature is not enabled.
 func (v *Vacuum) RemoteMove(params RemoteMoveParams) error {
+	err := v.requireFeatures(FeatureCodeRemote)
+	if err != nil {
+		return err
+	}
+
 	p, err := json.Marshal([]RemoteMoveParams{params})
 	if err != nil {
 		return err
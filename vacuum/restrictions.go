@@ -0,0 +1,140 @@
+package vacuum
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/l-ross/xiaomi/vacuum/rrmap"
+)
+
+// The types of restriction accepted by save_map.
+const (
+	restrictionTypeZone      = 0
+	restrictionTypeWall      = 1
+	restrictionTypeNoMopZone = 2
+)
+
+// maxMapCoordinate is the largest coordinate that can be stored in the map.
+const maxMapCoordinate = 0xffff
+
+// Restrictions are the areas of the map the vacuum must not enter (forbidden / no-go zones
+// and virtual walls) or must not mop.
+type Restrictions struct {
+	Zones      []rrmap.Polygon
+	Walls      []rrmap.Wall
+	NoMopZones []rrmap.Polygon
+}
+
+// RestrictionsFromMap returns the restrictions currently set in the map.
+func RestrictionsFromMap(m *rrmap.Map) *Restrictions {
+	return &Restrictions{
+		Zones:      m.ForbiddenZones,
+		Walls:      m.VirtualWalls,
+		NoMopZones: m.ForbiddenMopZones,
+	}
+}
+
+// ForbiddenZones downloads the current map and returns its restrictions, see DownloadMap.
+func (v *Vacuum) ForbiddenZones(ctx context.Context) (*Restrictions, error) {
+	m, err := v.DownloadMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return RestrictionsFromMap(m), nil
+}
+
+// SetForbiddenZones replaces all of the restrictions of the map with those provided.
+// Coordinates are in map coordinates (mm), see the mapcoord package for converting from
+// a rendered map.
+func (v *Vacuum) SetForbiddenZones(zones []rrmap.Polygon, walls []rrmap.Wall, noMopZones []rrmap.Polygon) error {
+	params := make([][]int, 0, len(zones)+len(walls)+len(noMopZones))
+
+	for i, z := range zones {
+		if err := validatePolygon(z); err != nil {
+			return fmt.Errorf("invalid zone %d: %w", i, err)
+		}
+
+		params = append(params, polygonParams(restrictionTypeZone, z))
+	}
+
+	for i, w := range walls {
+		if err := validateWall(w); err != nil {
+			return fmt.Errorf("invalid wall %d: %w", i, err)
+		}
+
+		params = append(params, []int{restrictionTypeWall, w.Start.X, w.Start.Y, w.End.X, w.End.Y})
+	}
+
+	for i, z := range noMopZones {
+		if err := validatePolygon(z); err != nil {
+			return fmt.Errorf("invalid no-mop zone %d: %w", i, err)
+		}
+
+		params = append(params, polygonParams(restrictionTypeNoMopZone, z))
+	}
+
+	p, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	return v.editMap(func() error {
+		return v.doParams("save_map", p)
+	})
+}
+
+func polygonParams(restrictionType int, z rrmap.Polygon) []int {
+	p := []int{restrictionType}
+	for _, pt := range z {
+		p = append(p, pt.X, pt.Y)
+	}
+
+	return p
+}
+
+// validatePolygon ensures the polygon is within the map and encloses an area.
+func validatePolygon(z rrmap.Polygon) error {
+	for _, p := range z {
+		if err := validatePoint(p); err != nil {
+			return err
+		}
+	}
+
+	// Shoelace formula, a zero area means the points are collinear or repeated.
+	area := 0
+	for i := range z {
+		j := (i + 1) % len(z)
+		area += z[i].X*z[j].Y - z[j].X*z[i].Y
+	}
+
+	if area == 0 {
+		return fmt.Errorf("polygon %v has no area", z)
+	}
+
+	return nil
+}
+
+// validateWall ensures the wall is within the map and has a length.
+func validateWall(w rrmap.Wall) error {
+	for _, p := range []rrmap.Point{w.Start, w.End} {
+		if err := validatePoint(p); err != nil {
+			return err
+		}
+	}
+
+	if w.Start == w.End {
+		return fmt.Errorf("wall %v has no length", w)
+	}
+
+	return nil
+}
+
+func validatePoint(p rrmap.Point) error {
+	if p.X < 0 || p.Y < 0 || p.X > maxMapCoordinate || p.Y > maxMapCoordinate {
+		return fmt.Errorf("point %v is outside of the map", p)
+	}
+
+	return nil
+}
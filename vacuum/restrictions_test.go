@@ -0,0 +1,83 @@
+package vacuum
+
+import (
+	"testing"
+
+	"github.com/l-ross/xiaomi/vacuum/rrmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVacuum_SetForbiddenZones(t *testing.T) {
+	t.Parallel()
+
+	c := &mockClient{}
+	v := &Vacuum{client: c}
+
+	err := v.SetForbiddenZones(
+		[]rrmap.Polygon{testPolygon(25500, 25500, 25600, 25500, 25600, 25600, 25500, 25600)},
+		[]rrmap.Wall{{Start: rrmap.Point{X: 25800, Y: 25450}, End: rrmap.Point{X: 25800, Y: 25700}}},
+		[]rrmap.Polygon{testPolygon(25700, 25500, 25800, 25500, 25800, 25600, 25700, 25600)},
+	)
+	require.NoError(t, err)
+	require.Equal(t, []string{"start_edit_map", "save_map", "end_edit_map"}, c.methods())
+	assert.JSONEq(t, `[
+		[0, 25500, 25500, 25600, 25500, 25600, 25600, 25500, 25600],
+		[1, 25800, 25450, 25800, 25700],
+		[2, 25700, 25500, 25800, 25500, 25800, 25600, 25700, 25600]
+	]`, string(c.reqs[1].Params))
+}
+
+func TestVacuum_SetForbiddenZones_Clear(t *testing.T) {
+	t.Parallel()
+
+	c := &mockClient{}
+	v := &Vacuum{client: c}
+
+	err := v.SetForbiddenZones(nil, nil, nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[]`, string(c.reqs[1].Params))
+}
+
+func TestVacuum_SetForbiddenZones_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		zones []rrmap.Polygon
+		walls []rrmap.Wall
+	}{
+		"collinear zone": {
+			zones: []rrmap.Polygon{testPolygon(0, 0, 100, 0, 200, 0, 300, 0)},
+		},
+		"zone outside map": {
+			zones: []rrmap.Polygon{testPolygon(-100, 0, 100, 0, 100, 100, -100, 100)},
+		},
+		"zero length wall": {
+			walls: []rrmap.Wall{{Start: rrmap.Point{X: 100, Y: 100}, End: rrmap.Point{X: 100, Y: 100}}},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &mockClient{}
+			v := &Vacuum{client: c}
+
+			err := v.SetForbiddenZones(tt.zones, tt.walls, nil)
+			require.Error(t, err)
+			assert.Empty(t, c.reqs)
+		})
+	}
+}
+
+// testPolygon returns a polygon from the provided x, y pairs.
+func testPolygon(coords ...int) rrmap.Polygon {
+	var p rrmap.Polygon
+	for i := range p {
+		p[i] = rrmap.Point{X: coords[i*2], Y: coords[i*2+1]}
+	}
+
+	return p
+}
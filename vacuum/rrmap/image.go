@@ -0,0 +1,101 @@
+package rrmap
+
+import (
+	"encoding/binary"
+	"sort"
+)
+
+type PixelType int
+
+const (
+	PixelOutside PixelType = iota
+	PixelWall
+	PixelFloor
+)
+
+// Image is the occupancy grid of the map. Each pixel covers PixelSize mm and the image is
+// offset from the origin of the map by Left and Top pixels.
+//
+// Pixels are stored row by row with y increasing along with the map's y axis, so the first
+// row is the bottom of the map when displayed.
+type Image struct {
+	Top    int
+	Left   int
+	Width  int
+	Height int
+	// SegmentCount is the number of segments reported by the vacuum, it is only set by
+	// newer firmware.
+	SegmentCount int
+	Pixels       []byte
+}
+
+func parseImage(header, data []byte) (*Image, error) {
+	if len(header) < 24 {
+		return nil, ErrTruncated
+	}
+
+	// Newer firmware inserts the segment count before the image position.
+	offset := 8
+	img := &Image{}
+
+	if len(header) >= 28 {
+		img.SegmentCount = int(int32(binary.LittleEndian.Uint32(header[offset:])))
+		offset += 4
+	}
+
+	img.Top = int(int32(binary.LittleEndian.Uint32(header[offset:])))
+	img.Left = int(int32(binary.LittleEndian.Uint32(header[offset+4:])))
+	img.Height = int(int32(binary.LittleEndian.Uint32(header[offset+8:])))
+	img.Width = int(int32(binary.LittleEndian.Uint32(header[offset+12:])))
+
+	if img.Width < 0 || img.Height < 0 || img.Width*img.Height > len(data) {
+		return nil, ErrTruncated
+	}
+
+	img.Pixels = data[:img.Width*img.Height]
+
+	return img, nil
+}
+
+// At returns the type of the pixel at x, y and the segment it belongs to, 0 if it does not
+// belong to a segment. Pixels outside of the image are PixelOutside.
+func (i *Image) At(x, y int) (PixelType, int) {
+	if x < 0 || y < 0 || x >= i.Width || y >= i.Height {
+		return PixelOutside, 0
+	}
+
+	return decodePixel(i.Pixels[y*i.Width+x])
+}
+
+// Segments returns the IDs of the segments present in the image, in ascending order.
+func (i *Image) Segments() []int {
+	seen := make(map[int]bool)
+
+	for _, p := range i.Pixels {
+		if _, s := decodePixel(p); s != 0 {
+			seen[s] = true
+		}
+	}
+
+	segments := make([]int, 0, len(seen))
+	for s := range seen {
+		segments = append(segments, s)
+	}
+
+	sort.Ints(segments)
+
+	return segments
+}
+
+// decodePixel decodes a pixel, the lower three bits hold the type of the pixel and for floor
+// pixels the upper five bits hold the segment.
+func decodePixel(p byte) (PixelType, int) {
+	switch p & 0x07 {
+	case 0:
+		return PixelOutside, 0
+	case 1:
+		return PixelWall, 0
+	default:
+		return PixelFloor, int(p >> 3)
+	}
+}
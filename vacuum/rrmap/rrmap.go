@@ -0,0 +1,427 @@
+// Package rrmap parses the RRMap format used by Roborock vacuums to describe their map.
+//
+// The map is retrieved from the vacuum as a (usually gzipped) file, see
+// vacuum.Vacuum.DownloadMap for details on how to retrieve it. The format is made up of a header followed by a number of
+// blocks, each describing a part of the map, and usually a SHA-1 digest of the contents.
+package rrmap
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	ErrInvalidMagic   = errors.New("invalid magic, not an RRMap")
+	ErrTruncated      = errors.New("map data is truncated")
+	ErrDigestMismatch = errors.New("map digest does not match contents")
+)
+
+// PixelSize is the size of each pixel of the map Image in map coordinates (mm).
+const PixelSize = 50
+
+type BlockType uint16
+
+const (
+	BlockTypeChargerLocation       BlockType = 1
+	BlockTypeImage                 BlockType = 2
+	BlockTypePath                  BlockType = 3
+	BlockTypeGoToPath              BlockType = 4
+	BlockTypeGoToPredictedPath     BlockType = 5
+	BlockTypeCurrentlyCleanedZones BlockType = 6
+	BlockTypeGoToTarget            BlockType = 7
+	BlockTypeRobotPosition         BlockType = 8
+	BlockTypeForbiddenZones        BlockType = 9
+	BlockTypeVirtualWalls          BlockType = 10
+	BlockTypeCleanedSegments       BlockType = 11
+	BlockTypeForbiddenMopZones     BlockType = 12
+	BlockTypeObstacles             BlockType = 13
+	BlockTypeDigest                BlockType = 1024
+)
+
+const (
+	magic        = "rr"
+	digestLength = sha1.Size
+	// blockHeaderLength is the minimum length of a block header: type, header length and data length.
+	blockHeaderLength = 8
+)
+
+type Header struct {
+	MajorVersion uint16
+	MinorVersion uint16
+	MapIndex     uint32
+	MapSequence  uint32
+}
+
+// Point is a position on the map in map coordinates (mm).
+type Point struct {
+	X int
+	Y int
+}
+
+// Position is a Point with the direction being faced in degrees.
+type Position struct {
+	Point
+	Angle int
+}
+
+// Path travelled, or to be travelled, by the vacuum.
+type Path struct {
+	Points []Point
+	// Angle is the current angle of the vacuum in degrees.
+	Angle int
+}
+
+// Zone is a rectangle on the map.
+type Zone struct {
+	Min Point
+	Max Point
+}
+
+// Polygon is a four-sided shape on the map, used for forbidden zones.
+type Polygon [4]Point
+
+// Wall is a virtual wall between two points on the map.
+type Wall struct {
+	Start Point
+	End   Point
+}
+
+type Obstacle struct {
+	Point
+	Type int
+	// Confidence is the percentage confidence of the detection, it is only reported by
+	// vacuums with obstacle recognition cameras.
+	Confidence int
+}
+
+// Block is a block of the map which is not understood by this package. These are kept so
+// callers can decode them.
+type Block struct {
+	Type   BlockType
+	Header []byte
+	Data   []byte
+}
+
+type Map struct {
+	Header Header
+
+	Charger           *Point
+	Image             *Image
+	Path              *Path
+	GoToPath          *Path
+	GoToPredictedPath *Path
+	GoToTarget        *Point
+	Robot             *Position
+
+	CleanedZones      []Zone
+	CleanedSegments   []int
+	ForbiddenZones    []Polygon
+	ForbiddenMopZones []Polygon
+	VirtualWalls      []Wall
+	Obstacles         []Obstacle
+
+	// Digest is only set if HasDigest, older firmware does not include it. The digest is
+	// verified when present.
+	Digest    [digestLength]byte
+	HasDigest bool
+	Unknown   []Block
+}
+
+// Parse reads a map from r, the map may be gzipped or raw.
+func Parse(r io.Reader) (*Map, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseBytes(b)
+}
+
+// ParseBytes parses a map from b, the map may be gzipped or raw.
+func ParseBytes(b []byte) (*Map, error) {
+	if len(b) >= 2 && b[0] == 0x1f && b[1] == 0x8b {
+		gr, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+
+		b, err = io.ReadAll(gr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress map: %w", err)
+		}
+	}
+
+	return parse(b)
+}
+
+func parse(b []byte) (*Map, error) {
+	if len(b) < 2 || string(b[0:2]) != magic {
+		return nil, ErrInvalidMagic
+	}
+
+	if len(b) < 0x14 {
+		return nil, ErrTruncated
+	}
+
+	headerLength := int(binary.LittleEndian.Uint16(b[0x02:]))
+	if headerLength < 0x14 || headerLength > len(b) {
+		return nil, ErrTruncated
+	}
+
+	m := &Map{
+		Header: Header{
+			MajorVersion: binary.LittleEndian.Uint16(b[0x08:]),
+			MinorVersion: binary.LittleEndian.Uint16(b[0x0A:]),
+			MapIndex:     binary.LittleEndian.Uint32(b[0x0C:]),
+			MapSequence:  binary.LittleEndian.Uint32(b[0x10:]),
+		},
+	}
+
+	for offset := headerLength; offset < len(b); {
+		if len(b)-offset < blockHeaderLength {
+			return nil, ErrTruncated
+		}
+
+		blockType := BlockType(binary.LittleEndian.Uint16(b[offset:]))
+		hLen := int(binary.LittleEndian.Uint16(b[offset+2:]))
+		dLen := int(binary.LittleEndian.Uint32(b[offset+4:]))
+
+		if hLen < blockHeaderLength || dLen < 0 || len(b)-offset < hLen || len(b)-offset-hLen < dLen {
+			return nil, ErrTruncated
+		}
+
+		header := b[offset : offset+hLen]
+		data := b[offset+hLen : offset+hLen+dLen]
+
+		if blockType == BlockTypeDigest {
+			if dLen != digestLength {
+				return nil, fmt.Errorf("invalid digest length %d", dLen)
+			}
+
+			if sha1.Sum(b[:offset+hLen]) != sha1Array(data) {
+				return nil, ErrDigestMismatch
+			}
+
+			copy(m.Digest[:], data)
+			m.HasDigest = true
+		} else {
+			err := m.parseBlock(blockType, header, data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse block %d: %w", blockType, err)
+			}
+		}
+
+		offset += hLen + dLen
+	}
+
+	return m, nil
+}
+
+func (m *Map) parseBlock(t BlockType, header, data []byte) error {
+	var err error
+
+	switch t {
+	case BlockTypeChargerLocation:
+		m.Charger, err = parsePoint32(data)
+	case BlockTypeImage:
+		m.Image, err = parseImage(header, data)
+	case BlockTypePath:
+		m.Path, err = parsePath(header, data)
+	case BlockTypeGoToPath:
+		m.GoToPath, err = parsePath(header, data)
+	case BlockTypeGoToPredictedPath:
+		m.GoToPredictedPath, err = parsePath(header, data)
+	case BlockTypeGoToTarget:
+		if len(data) < 4 {
+			return ErrTruncated
+		}
+
+		m.GoToTarget = &Point{
+			X: int(binary.LittleEndian.Uint16(data)),
+			Y: int(binary.LittleEndian.Uint16(data[2:])),
+		}
+	case BlockTypeRobotPosition:
+		m.Robot, err = parsePosition(data)
+	case BlockTypeCurrentlyCleanedZones:
+		err = forEachEntry(header, data, 8, func(e []byte) {
+			v := uint16s(e)
+			m.CleanedZones = append(m.CleanedZones, Zone{
+				Min: Point{X: v[0], Y: v[1]},
+				Max: Point{X: v[2], Y: v[3]},
+			})
+		})
+	case BlockTypeForbiddenZones:
+		m.ForbiddenZones, err = parsePolygons(header, data)
+	case BlockTypeForbiddenMopZones:
+		m.ForbiddenMopZones, err = parsePolygons(header, data)
+	case BlockTypeVirtualWalls:
+		err = forEachEntry(header, data, 8, func(e []byte) {
+			v := uint16s(e)
+			m.VirtualWalls = append(m.VirtualWalls, Wall{
+				Start: Point{X: v[0], Y: v[1]},
+				End:   Point{X: v[2], Y: v[3]},
+			})
+		})
+	case BlockTypeCleanedSegments:
+		err = forEachEntry(header, data, 1, func(e []byte) {
+			m.CleanedSegments = append(m.CleanedSegments, int(e[0]))
+		})
+	case BlockTypeObstacles:
+		m.Obstacles, err = parseObstacles(header, data)
+	default:
+		m.Unknown = append(m.Unknown, Block{
+			Type:   t,
+			Header: header,
+			Data:   data,
+		})
+	}
+
+	return err
+}
+
+// parsePoint32 parses a point made up of two int32s.
+func parsePoint32(data []byte) (*Point, error) {
+	if len(data) < 8 {
+		return nil, ErrTruncated
+	}
+
+	return &Point{
+		X: int(int32(binary.LittleEndian.Uint32(data))),
+		Y: int(int32(binary.LittleEndian.Uint32(data[4:]))),
+	}, nil
+}
+
+func parsePosition(data []byte) (*Position, error) {
+	p, err := parsePoint32(data)
+	if err != nil {
+		return nil, err
+	}
+
+	pos := &Position{Point: *p}
+
+	// Older firmware does not report the angle.
+	if len(data) >= 12 {
+		pos.Angle = int(int32(binary.LittleEndian.Uint32(data[8:])))
+	}
+
+	return pos, nil
+}
+
+func parsePath(header, data []byte) (*Path, error) {
+	if len(header) < 20 {
+		return nil, ErrTruncated
+	}
+
+	p := &Path{
+		Points: make([]Point, 0, len(data)/4),
+		Angle:  int(int32(binary.LittleEndian.Uint32(header[16:]))),
+	}
+
+	for i := 0; i+4 <= len(data); i += 4 {
+		p.Points = append(p.Points, Point{
+			X: int(binary.LittleEndian.Uint16(data[i:])),
+			Y: int(binary.LittleEndian.Uint16(data[i+2:])),
+		})
+	}
+
+	return p, nil
+}
+
+func parsePolygons(header, data []byte) ([]Polygon, error) {
+	polygons := make([]Polygon, 0)
+
+	err := forEachEntry(header, data, 16, func(e []byte) {
+		v := uint16s(e)
+
+		var p Polygon
+		for i := range p {
+			p[i] = Point{X: v[i*2], Y: v[i*2+1]}
+		}
+
+		polygons = append(polygons, p)
+	})
+
+	return polygons, err
+}
+
+func parseObstacles(header, data []byte) ([]Obstacle, error) {
+	count, err := entryCount(header)
+	if err != nil || count == 0 {
+		return nil, err
+	}
+
+	// The size of each obstacle depends on the firmware, newer firmware adds the
+	// confidence and photo details.
+	size := len(data) / count
+	if size < 6 {
+		return nil, ErrTruncated
+	}
+
+	obstacles := make([]Obstacle, 0, count)
+
+	err = forEachEntry(header, data, size, func(e []byte) {
+		o := Obstacle{
+			Point: Point{
+				X: int(binary.LittleEndian.Uint16(e)),
+				Y: int(binary.LittleEndian.Uint16(e[2:])),
+			},
+			Type: int(binary.LittleEndian.Uint16(e[4:])),
+		}
+
+		if size >= 8 {
+			o.Confidence = int(binary.LittleEndian.Uint16(e[6:]))
+		}
+
+		obstacles = append(obstacles, o)
+	})
+
+	return obstacles, err
+}
+
+// entryCount returns the number of entries in a block, stored after the block header.
+func entryCount(header []byte) (int, error) {
+	if len(header) < 12 {
+		return 0, ErrTruncated
+	}
+
+	return int(binary.LittleEndian.Uint32(header[8:])), nil
+}
+
+// forEachEntry calls fn for each of the fixed size entries in data.
+func forEachEntry(header, data []byte, size int, fn func(e []byte)) error {
+	count, err := entryCount(header)
+	if err != nil {
+		return err
+	}
+
+	if count*size > len(data) {
+		return ErrTruncated
+	}
+
+	for i := 0; i < count; i++ {
+		fn(data[i*size : (i+1)*size])
+	}
+
+	return nil
+}
+
+// uint16s decodes b as a list of uint16s.
+func uint16s(b []byte) []int {
+	v := make([]int, len(b)/2)
+	for i := range v {
+		v[i] = int(binary.LittleEndian.Uint16(b[i*2:]))
+	}
+
+	return v
+}
+
+func sha1Array(b []byte) [digestLength]byte {
+	var a [digestLength]byte
+	copy(a[:], b)
+	return a
+}
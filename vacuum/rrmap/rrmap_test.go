@@ -0,0 +1,140 @@
+package rrmap
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"map.rrmap", "map.rrmap.gz"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := os.Open("testdata/" + name)
+			require.NoError(t, err)
+			defer f.Close()
+
+			m, err := Parse(f)
+			require.NoError(t, err)
+
+			assert.Equal(t, Header{MajorVersion: 1, MinorVersion: 17, MapSequence: 42}, m.Header)
+			assert.Equal(t, &Point{X: 25600, Y: 25600}, m.Charger)
+			assert.Equal(t, &Position{Point: Point{X: 25700, Y: 25800}, Angle: 90}, m.Robot)
+			assert.Equal(t, &Point{X: 25900, Y: 25750}, m.GoToTarget)
+
+			require.NotNil(t, m.Path)
+			assert.Equal(t, 90, m.Path.Angle)
+			assert.Equal(t, []Point{{25600, 25600}, {25700, 25600}, {25700, 25800}}, m.Path.Points)
+			assert.Nil(t, m.GoToPath)
+			require.NotNil(t, m.GoToPredictedPath)
+			assert.Len(t, m.GoToPredictedPath.Points, 2)
+
+			assert.Equal(t, []Zone{{Min: Point{25450, 25450}, Max: Point{25750, 25700}}}, m.CleanedZones)
+			assert.Equal(t, []int{16, 17}, m.CleanedSegments)
+			assert.Equal(t, []Polygon{{{25500, 25500}, {25600, 25500}, {25600, 25600}, {25500, 25600}}}, m.ForbiddenZones)
+			assert.Equal(t, []Polygon{{{25700, 25500}, {25800, 25500}, {25800, 25600}, {25700, 25600}}}, m.ForbiddenMopZones)
+			assert.Equal(t, []Wall{{Start: Point{25800, 25450}, End: Point{25800, 25700}}}, m.VirtualWalls)
+			assert.Equal(t, []Obstacle{
+				{Point: Point{25650, 25550}, Type: 2, Confidence: 87},
+				{Point: Point{25750, 25650}, Type: 5, Confidence: 64},
+			}, m.Obstacles)
+
+			require.Len(t, m.Unknown, 1)
+			assert.Equal(t, BlockType(17), m.Unknown[0].Type)
+			assert.Equal(t, []byte{1, 2, 3}, m.Unknown[0].Data)
+
+			img := m.Image
+			require.NotNil(t, img)
+			assert.Equal(t, 508, img.Top)
+			assert.Equal(t, 508, img.Left)
+			assert.Equal(t, 8, img.Width)
+			assert.Equal(t, 6, img.Height)
+			assert.Equal(t, 2, img.SegmentCount)
+			assert.Equal(t, []int{16, 17}, img.Segments())
+		})
+	}
+}
+
+func TestImage_At(t *testing.T) {
+	t.Parallel()
+
+	m := parseFixture(t)
+
+	tests := map[string]struct {
+		x, y        int
+		wantType    PixelType
+		wantSegment int
+	}{
+		"wall":      {x: 0, y: 0, wantType: PixelWall},
+		"segment16": {x: 2, y: 2, wantType: PixelFloor, wantSegment: 16},
+		"segment17": {x: 5, y: 2, wantType: PixelFloor, wantSegment: 17},
+		"floor":     {x: 6, y: 4, wantType: PixelFloor},
+		"outside":   {x: 8, y: 2, wantType: PixelOutside},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			gotType, gotSegment := m.Image.At(tt.x, tt.y)
+			assert.Equal(t, tt.wantType, gotType)
+			assert.Equal(t, tt.wantSegment, gotSegment)
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	t.Parallel()
+
+	b, err := os.ReadFile("testdata/map.rrmap")
+	require.NoError(t, err)
+
+	_, err = ParseBytes([]byte("not a map"))
+	assert.ErrorIs(t, err, ErrInvalidMagic)
+
+	_, err = ParseBytes(b[:len(b)-10])
+	assert.ErrorIs(t, err, ErrTruncated)
+
+	corrupt := append([]byte{}, b...)
+	corrupt[30]++
+
+	_, err = ParseBytes(corrupt)
+	assert.ErrorIs(t, err, ErrDigestMismatch)
+}
+
+func TestParse_NoDigest(t *testing.T) {
+	t.Parallel()
+
+	b, err := os.ReadFile("testdata/map.rrmap")
+	require.NoError(t, err)
+
+	m, err := ParseBytes(b)
+	require.NoError(t, err)
+	assert.True(t, m.HasDigest)
+
+	// The digest block is the final block.
+	m, err = ParseBytes(b[:len(b)-blockHeaderLength-digestLength])
+	require.NoError(t, err)
+	assert.False(t, m.HasDigest)
+	assert.Equal(t, [digestLength]byte{}, m.Digest)
+	assert.NotNil(t, m.Image)
+}
+
+func parseFixture(t *testing.T) *Map {
+	t.Helper()
+
+	b, err := os.ReadFile("testdata/map.rrmap")
+	require.NoError(t, err)
+
+	m, err := ParseBytes(b)
+	require.NoError(t, err)
+
+	return m
+}
@@ -0,0 +1,187 @@
+package vacuum
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// MaxSegmentCleanRepeats is the maximum number of times a segment can be cleaned in one run.
+const MaxSegmentCleanRepeats = 3
+
+// SegmentClean starts cleaning the provided segments (rooms), each segment is cleaned the
+// given number of times.
+//
+// The segment IDs can be retrieved via GetRoomMapping.
+func (v *Vacuum) SegmentClean(ids []int, repeats int) error {
+	if len(ids) == 0 {
+		return errors.New("at least one segment must be provided")
+	}
+
+	if repeats < 1 || repeats > MaxSegmentCleanRepeats {
+		return fmt.Errorf("repeats must be between 1 and %d", MaxSegmentCleanRepeats)
+	}
+
+	err := v.requireMapFeatures(FeatureCodeMapSegment, FeatureCodeOrderSegmentClean)
+	if err != nil {
+		return err
+	}
+
+	var params interface{} = ids
+
+	// Older firmware only accepts a list of segments, so only send the extended
+	// form when it is needed.
+	if repeats > 1 {
+		params = []interface{}{
+			map[string]interface{}{
+				"segments": ids,
+				"repeat":   repeats,
+			},
+		}
+	}
+
+	p, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	return v.doParams("app_segment_clean", p)
+}
+
+// StopSegmentClean stops the current segment clean.
+func (v *Vacuum) StopSegmentClean() error {
+	return v.doSimple("stop_segment_clean")
+}
+
+// ResumeSegmentClean resumes a paused segment clean.
+func (v *Vacuum) ResumeSegmentClean() error {
+	return v.doSimple("resume_segment_clean")
+}
+
+type RoomMapping struct {
+	// SegmentID is the ID of the segment in the vacuums map.
+	SegmentID int
+	// RoomID is the ID of the room assigned by the Xiaomi / Roborock app.
+	RoomID string
+}
+
+// GetRoomMapping retrieves the mapping of map segments to rooms.
+func (v *Vacuum) GetRoomMapping() ([]RoomMapping, error) {
+	err := v.requireMapFeatures(FeatureCodeMapSegment)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each mapping is of the form [segment_id, "room_id"], newer firmware appends
+	// additional fields which are ignored.
+	rsp := make([][]json.RawMessage, 0)
+
+	err = v.do("get_room_mapping", nil, &rsp)
+	if err != nil {
+		return nil, err
+	}
+
+	mappings := make([]RoomMapping, len(rsp))
+
+	for i, m := range rsp {
+		if len(m) < 2 {
+			return nil, ErrUnexpectedResponse
+		}
+
+		err = json.Unmarshal(m[0], &mappings[i].SegmentID)
+		if err != nil {
+			return nil, err
+		}
+
+		// The room ID is usually a string but some firmware returns a number.
+		var roomID interface{}
+
+		err = json.Unmarshal(m[1], &roomID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch id := roomID.(type) {
+		case string:
+			mappings[i].RoomID = id
+		case float64:
+			mappings[i].RoomID = strconv.FormatInt(int64(id), 10)
+		default:
+			return nil, ErrUnexpectedResponse
+		}
+	}
+
+	return mappings, nil
+}
+
+// GetSegmentStatus retrieves the segment status of the vacuums map, a non-zero value
+// indicates the map has been split into segments.
+func (v *Vacuum) GetSegmentStatus() (int, error) {
+	err := v.requireMapFeatures(FeatureCodeMapSegment)
+	if err != nil {
+		return 0, err
+	}
+
+	rsp := make([]int, 0)
+
+	err = v.do("get_segment_status", nil, &rsp)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(rsp) != 1 {
+		return 0, ErrUnexpectedResponse
+	}
+
+	return rsp[0], nil
+}
+
+// NameSegment sets the name of a segment.
+func (v *Vacuum) NameSegment(id int, name string) error {
+	if name == "" {
+		return errors.New("name must not be empty")
+	}
+
+	p, err := json.Marshal([]interface{}{id, name})
+	if err != nil {
+		return err
+	}
+
+	return v.editMap(func() error {
+		return v.doParams("name_segment", p)
+	})
+}
+
+// MergeSegments merges two adjacent segments into one.
+func (v *Vacuum) MergeSegments(a, b int) error {
+	if a == b {
+		return errors.New("cannot merge a segment with itself")
+	}
+
+	p, err := json.Marshal([]int{a, b})
+	if err != nil {
+		return err
+	}
+
+	return v.editMap(func() error {
+		return v.doParams("merge_segment", p)
+	})
+}
+
+// SplitSegment splits a segment in two along the line from (x1, y1) to (x2, y2).
+// The coordinates are in map coordinates (mm).
+func (v *Vacuum) SplitSegment(id, x1, y1, x2, y2 int) error {
+	if x1 == x2 && y1 == y2 {
+		return errors.New("split line must have a non-zero length")
+	}
+
+	p, err := json.Marshal([]int{id, x1, y1, x2, y2})
+	if err != nil {
+		return err
+	}
+
+	return v.editMap(func() error {
+		return v.doParams("split_segment", p)
+	})
+}
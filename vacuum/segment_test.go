@@ -0,0 +1,200 @@
+package vacuum
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testFeatures = []byte(`
+	{
+		"result": [111, 112, 113, 114, 115, 116, 117, 118, 119, 122, 125],
+		"id": 1
+	}`,
+)
+
+func TestVacuum_SegmentClean(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		ids        []int
+		repeats    int
+		wantParams string
+	}{
+		"single": {
+			ids:        []int{16, 17},
+			repeats:    1,
+			wantParams: `[16,17]`,
+		},
+		"repeat": {
+			ids:        []int{16},
+			repeats:    2,
+			wantParams: `[{"repeat":2,"segments":[16]}]`,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &mockClient{
+				rsps: map[string][][]byte{
+					"get_fw_features": {testFeatures},
+				},
+			}
+			v := &Vacuum{client: c}
+
+			err := v.SegmentClean(tt.ids, tt.repeats)
+			require.NoError(t, err)
+			require.Equal(t, []string{"get_fw_features", "app_segment_clean"}, c.methods())
+			assert.JSONEq(t, tt.wantParams, string(c.reqs[1].Params))
+		})
+	}
+}
+
+func TestVacuum_SegmentClean_NotSupported(t *testing.T) {
+	t.Parallel()
+
+	c := &mockClient{
+		rsps: map[string][][]byte{
+			"get_fw_features": {[]byte(`{"result": [111, 112, 113], "id": 1}`)},
+		},
+	}
+	v := &Vacuum{client: c}
+
+	err := v.SegmentClean([]int{16}, 1)
+	require.True(t, errors.Is(err, ErrNotSupported))
+	assert.Equal(t, []string{"get_fw_features"}, c.methods())
+}
+
+func TestVacuum_SegmentClean_InvalidParams(t *testing.T) {
+	t.Parallel()
+
+	v := &Vacuum{client: &mockClient{}}
+
+	err := v.SegmentClean(nil, 1)
+	require.Error(t, err)
+
+	err = v.SegmentClean([]int{16}, 4)
+	require.Error(t, err)
+}
+
+func TestVacuum_GetRoomMapping(t *testing.T) {
+	t.Parallel()
+
+	v := &Vacuum{
+		client: &mockClient{
+			rsps: map[string][][]byte{
+				"get_fw_features": {testFeatures},
+				"get_room_mapping": {[]byte(`
+					{
+						"result": [[16, "14"], [17, "12", 6], [18, 3]],
+						"id": 1
+					}`,
+				)},
+			},
+		},
+	}
+
+	got, err := v.GetRoomMapping()
+	require.NoError(t, err)
+	assert.Equal(t, []RoomMapping{
+		{SegmentID: 16, RoomID: "14"},
+		{SegmentID: 17, RoomID: "12"},
+		{SegmentID: 18, RoomID: "3"},
+	}, got)
+}
+
+func TestVacuum_GetSegmentStatus(t *testing.T) {
+	t.Parallel()
+
+	v := &Vacuum{
+		client: &mockClient{
+			rsps: map[string][][]byte{
+				"get_fw_features":    {testFeatures},
+				"get_segment_status": {[]byte(`{"result": [1], "id": 1}`)},
+			},
+		},
+	}
+
+	got, err := v.GetSegmentStatus()
+	require.NoError(t, err)
+	assert.Equal(t, 1, got)
+}
+
+func TestVacuum_EditSegments(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		edit       func(v *Vacuum) error
+		method     string
+		wantParams string
+	}{
+		"name": {
+			edit:       func(v *Vacuum) error { return v.NameSegment(16, "Kitchen") },
+			method:     "name_segment",
+			wantParams: `[16, "Kitchen"]`,
+		},
+		"merge": {
+			edit:       func(v *Vacuum) error { return v.MergeSegments(16, 17) },
+			method:     "merge_segment",
+			wantParams: `[16, 17]`,
+		},
+		"split": {
+			edit:       func(v *Vacuum) error { return v.SplitSegment(16, 25000, 25000, 27000, 25000) },
+			method:     "split_segment",
+			wantParams: `[16, 25000, 25000, 27000, 25000]`,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &mockClient{}
+			v := &Vacuum{client: c}
+
+			err := tt.edit(v)
+			require.NoError(t, err)
+			require.Equal(t, []string{"start_edit_map", tt.method, "end_edit_map"}, c.methods())
+			assert.JSONEq(t, tt.wantParams, string(c.reqs[1].Params))
+		})
+	}
+}
+
+func TestVacuum_EditSegments_Rollback(t *testing.T) {
+	t.Parallel()
+
+	c := &mockClient{
+		rsps: map[string][][]byte{
+			"merge_segment": {[]byte(`{"error": {"code": -1, "message": "merge failed"}, "id": 1}`)},
+		},
+	}
+	v := &Vacuum{client: c}
+
+	err := v.MergeSegments(16, 17)
+	require.Error(t, err)
+	require.Equal(t, []string{"start_edit_map", "merge_segment", "end_edit_map"}, c.methods())
+	assert.JSONEq(t, `[0]`, string(c.reqs[2].Params))
+}
+
+func TestVacuum_EditSegments_RollbackFailed(t *testing.T) {
+	t.Parallel()
+
+	c := &mockClient{
+		rsps: map[string][][]byte{
+			"merge_segment": {[]byte(`{"error": {"code": -32601, "message": "Method not found."}, "id": 1}`)},
+			"end_edit_map":  {[]byte(`{"error": {"code": -1, "message": "rollback failed"}, "id": 1}`)},
+		},
+	}
+	v := &Vacuum{client: c}
+
+	err := v.MergeSegments(16, 17)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrNotSupported))
+	assert.Contains(t, err.Error(), "rollback failed")
+}
@@ -0,0 +1,264 @@
+package vacuum
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/l-ross/xiaomi/miio"
+)
+
+// soundPackPath is the path the sound pack is served at, it is fixed so that the name of the
+// file does not need escaping.
+const soundPackPath = "/voice.pkg"
+
+// soundProgressBuffer is the number of progress updates buffered for a slow reader.
+const soundProgressBuffer = 8
+
+// customSoundPackID is the sound ID used for sound packs which are not provided by Xiaomi.
+const customSoundPackID = 10000
+
+type SoundInstallState int
+
+const (
+	SoundInstallStateUnknown     SoundInstallState = 0
+	SoundInstallStateDownloading SoundInstallState = 1
+	SoundInstallStateInstalling  SoundInstallState = 2
+	SoundInstallStateInstalled   SoundInstallState = 3
+	SoundInstallStateError       SoundInstallState = 4
+)
+
+type SoundProgress struct {
+	SoundID int               `json:"sid_in_progress"`
+	State   SoundInstallState `json:"state"`
+	// Progress percentage of the current State.
+	Progress  int `json:"progress"`
+	ErrorCode int `json:"error"`
+	// Err is set if the installation failed, could not be monitored or ctx is done, it is
+	// always the final progress sent.
+	Err error `json:"-"`
+}
+
+type CurrentSound struct {
+	SoundID int `json:"sid_in_use"`
+	Version int `json:"sid_version"`
+}
+
+// GetCurrentSound retrieves the sound (voice) pack in use.
+func (v *Vacuum) GetCurrentSound() (*CurrentSound, error) {
+	rsp := make([]*CurrentSound, 0)
+
+	err := v.do("get_current_sound", nil, &rsp)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rsp) != 1 {
+		return nil, ErrUnexpectedResponse
+	}
+
+	return rsp[0], nil
+}
+
+// SoundProgress retrieves the progress of the sound pack being installed.
+func (v *Vacuum) SoundProgress() (*SoundProgress, error) {
+	return v.soundProgress("get_sound_progress", nil)
+}
+
+func (v *Vacuum) soundProgress(method string, params json.RawMessage) (*SoundProgress, error) {
+	rsp := make([]*SoundProgress, 0)
+
+	err := v.do(method, params, &rsp)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rsp) != 1 {
+		return nil, ErrUnexpectedResponse
+	}
+
+	return rsp[0], nil
+}
+
+// InstallSoundPack installs the sound (voice) pack at path.
+//
+// The vacuum downloads the pack itself, so it is served from a temporary HTTP server
+// listening on the local interface used to reach the vacuum. The progress of the
+// installation is sent on the returned channel, which is closed once installation has
+// completed, failed or ctx is done. The HTTP server is stopped when the channel is closed.
+//
+// Sending progress never blocks, if the channel is not read promptly the oldest progress is
+// discarded. The final progress is always delivered.
+func (v *Vacuum) InstallSoundPack(ctx context.Context, path string) (<-chan SoundProgress, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	h := md5.New()
+
+	_, err = io.Copy(h, f)
+	f.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	localIP, err := v.localIP()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find local IP: %w", err)
+	}
+
+	l, err := net.Listen("tcp", net.JoinHostPort(localIP.String(), "0"))
+	if err != nil {
+		return nil, err
+	}
+
+	srv := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != soundPackPath {
+				http.NotFound(w, r)
+				return
+			}
+
+			http.ServeFile(w, r, path)
+		}),
+	}
+
+	go func() {
+		_ = srv.Serve(l)
+	}()
+
+	p, err := json.Marshal(map[string]interface{}{
+		"url": "http://" + l.Addr().String() + soundPackPath,
+		"md5": hex.EncodeToString(h.Sum(nil)),
+		"sid": customSoundPackID,
+	})
+	if err != nil {
+		srv.Close()
+		return nil, err
+	}
+
+	progress, err := v.soundProgress("dnld_install_sound", p)
+	if err != nil {
+		srv.Close()
+		return nil, err
+	}
+
+	ch := make(chan SoundProgress, soundProgressBuffer)
+
+	go func() {
+		defer close(ch)
+		defer srv.Close()
+
+		ticker := time.NewTicker(v.getPollInterval())
+		defer ticker.Stop()
+
+		for {
+			if progress.State == SoundInstallStateError {
+				progress.Err = fmt.Errorf("sound pack installation failed with error %d", progress.ErrorCode)
+			}
+
+			sendLatestProgress(ch, *progress)
+
+			if progress.State == SoundInstallStateInstalled || progress.Err != nil {
+				return
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				sendLatestProgress(ch, SoundProgress{Err: ctx.Err()})
+				return
+			}
+
+			progress, err = v.SoundProgress()
+			if err != nil {
+				progress = &SoundProgress{Err: err}
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// sendLatestProgress sends p on ch without blocking, discarding the oldest progress if ch is
+// full. This must only be called by the sender of ch.
+func sendLatestProgress(ch chan SoundProgress, p SoundProgress) {
+	for {
+		select {
+		case ch <- p:
+			return
+		default:
+		}
+
+		select {
+		case <-ch:
+		default:
+		}
+	}
+}
+
+// localIP returns the IP of the local interface used to reach the Vacuum.
+func (v *Vacuum) localIP() (net.IP, error) {
+	info, err := v.Info()
+	if err != nil {
+		return nil, err
+	}
+
+	if info.Network.LocalIP == "" {
+		return nil, errors.New("vacuum did not report its IP")
+	}
+
+	// Dialing UDP does not send any packets but selects the local address to use.
+	conn, err := net.Dial("udp", net.JoinHostPort(info.Network.LocalIP, fmt.Sprint(miio.DefaultPort)))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return conn.LocalAddr().(*net.UDPAddr).IP, nil
+}
+
+// Volume retrieves the volume of the Vacuum's voice as a percentage.
+func (v *Vacuum) Volume() (int, error) {
+	rsp := make([]int, 0)
+
+	err := v.do("get_sound_volume", nil, &rsp)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(rsp) != 1 {
+		return 0, ErrUnexpectedResponse
+	}
+
+	return rsp[0], nil
+}
+
+// SetVolume sets the volume of the Vacuum's voice as a percentage (0-100).
+func (v *Vacuum) SetVolume(percent int) error {
+	if percent < 0 || percent > 100 {
+		return fmt.Errorf("volume %d must be between 0 and 100", percent)
+	}
+
+	p, err := json.Marshal([]int{percent})
+	if err != nil {
+		return err
+	}
+
+	return v.doParams("change_sound_volume", p)
+}
+
+// TestVolume plays a sound at the current volume.
+func (v *Vacuum) TestVolume() error {
+	return v.doSimple("test_sound_volume")
+}
@@ -0,0 +1,271 @@
+package vacuum
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSoundProgress(state SoundInstallState, progress, errorCode int) []byte {
+	b, _ := json.Marshal(map[string]interface{}{
+		"id": 1,
+		"result": []interface{}{
+			map[string]int{
+				"sid_in_progress": customSoundPackID,
+				"state":           int(state),
+				"progress":        progress,
+				"error":           errorCode,
+			},
+		},
+	})
+
+	return b
+}
+
+func TestVacuum_InstallSoundPack(t *testing.T) {
+	t.Parallel()
+
+	pack := []byte("voice pack contents")
+	// The name must not need escaping to be served.
+	path := filepath.Join(t.TempDir(), "my voice #1?.pkg")
+	require.NoError(t, os.WriteFile(path, pack, 0o600))
+
+	sum := md5.Sum(pack)
+
+	var downloaded []byte
+
+	c := &mockClient{
+		rsps: map[string][][]byte{
+			"miIO.info":          {[]byte(`{"result": {"netif": {"localIp": "127.0.0.1"}}, "id": 1}`)},
+			"dnld_install_sound": {testSoundProgress(SoundInstallStateDownloading, 0, 0)},
+			"get_sound_progress": {
+				testSoundProgress(SoundInstallStateInstalling, 50, 0),
+				testSoundProgress(SoundInstallStateInstalled, 100, 0),
+			},
+		},
+	}
+
+	// Download the pack as the vacuum would once installation has been requested.
+	c.hook = func(req *request) {
+		if req.Method != "dnld_install_sound" {
+			return
+		}
+
+		params := struct {
+			URL string `json:"url"`
+			MD5 string `json:"md5"`
+			SID int    `json:"sid"`
+		}{}
+		require.NoError(t, json.Unmarshal(req.Params, &params))
+		assert.Equal(t, hex.EncodeToString(sum[:]), params.MD5)
+		assert.Equal(t, customSoundPackID, params.SID)
+
+		rsp, err := http.Get(params.URL)
+		require.NoError(t, err)
+		defer rsp.Body.Close()
+
+		downloaded, err = io.ReadAll(rsp.Body)
+		require.NoError(t, err)
+	}
+
+	v := &Vacuum{
+		client:  c,
+		options: &Options{PollInterval: time.Millisecond},
+	}
+
+	ch, err := v.InstallSoundPack(context.Background(), path)
+	require.NoError(t, err)
+
+	states := make([]SoundInstallState, 0)
+	for p := range ch {
+		require.NoError(t, p.Err)
+		states = append(states, p.State)
+	}
+
+	assert.Equal(t, pack, downloaded)
+	assert.Equal(t, []SoundInstallState{
+		SoundInstallStateDownloading,
+		SoundInstallStateInstalling,
+		SoundInstallStateInstalled,
+	}, states)
+}
+
+func TestVacuum_InstallSoundPack_Unread(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "voice.pkg")
+	require.NoError(t, os.WriteFile(path, []byte("voice pack contents"), 0o600))
+
+	var url string
+
+	c := &mockClient{
+		rsps: map[string][][]byte{
+			"miIO.info":          {[]byte(`{"result": {"netif": {"localIp": "127.0.0.1"}}, "id": 1}`)},
+			"dnld_install_sound": {testSoundProgress(SoundInstallStateDownloading, 0, 0)},
+			"get_sound_progress": {
+				testSoundProgress(SoundInstallStateDownloading, 10, 0),
+				testSoundProgress(SoundInstallStateDownloading, 20, 0),
+				testSoundProgress(SoundInstallStateDownloading, 30, 0),
+				testSoundProgress(SoundInstallStateDownloading, 40, 0),
+				testSoundProgress(SoundInstallStateDownloading, 50, 0),
+				testSoundProgress(SoundInstallStateDownloading, 60, 0),
+				testSoundProgress(SoundInstallStateDownloading, 70, 0),
+				testSoundProgress(SoundInstallStateDownloading, 80, 0),
+				testSoundProgress(SoundInstallStateDownloading, 90, 0),
+				testSoundProgress(SoundInstallStateInstalled, 100, 0),
+			},
+		},
+	}
+	c.hook = func(req *request) {
+		if req.Method == "dnld_install_sound" {
+			params := struct {
+				URL string `json:"url"`
+			}{}
+			require.NoError(t, json.Unmarshal(req.Params, &params))
+			url = params.URL
+		}
+	}
+
+	v := &Vacuum{
+		client:  c,
+		options: &Options{PollInterval: time.Millisecond},
+	}
+
+	ch, err := v.InstallSoundPack(context.Background(), path)
+	require.NoError(t, err)
+
+	// Installation completes, and the server is stopped, without the progress being read.
+	require.Eventually(t, func() bool {
+		rsp, err := http.Get(url)
+		if err != nil {
+			return true
+		}
+
+		rsp.Body.Close()
+		return false
+	}, time.Second, time.Millisecond)
+
+	var last SoundProgress
+	for p := range ch {
+		last = p
+	}
+
+	assert.Equal(t, SoundInstallStateInstalled, last.State)
+}
+
+func TestVacuum_InstallSoundPack_Cancel(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "voice.pkg")
+	require.NoError(t, os.WriteFile(path, []byte("voice pack contents"), 0o600))
+
+	v := &Vacuum{
+		client: &mockClient{
+			rsps: map[string][][]byte{
+				"miIO.info":          {[]byte(`{"result": {"netif": {"localIp": "127.0.0.1"}}, "id": 1}`)},
+				"dnld_install_sound": {testSoundProgress(SoundInstallStateDownloading, 0, 0)},
+			},
+		},
+		options: &Options{PollInterval: time.Hour},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ch, err := v.InstallSoundPack(ctx, path)
+	require.NoError(t, err)
+
+	first := <-ch
+	assert.Equal(t, SoundInstallStateDownloading, first.State)
+
+	cancel()
+
+	var last SoundProgress
+	for p := range ch {
+		last = p
+	}
+
+	assert.True(t, errors.Is(last.Err, context.Canceled))
+}
+
+func TestVacuum_InstallSoundPack_Error(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "voice.pkg")
+	require.NoError(t, os.WriteFile(path, []byte("invalid"), 0o600))
+
+	v := &Vacuum{
+		client: &mockClient{
+			rsps: map[string][][]byte{
+				"miIO.info":          {[]byte(`{"result": {"netif": {"localIp": "127.0.0.1"}}, "id": 1}`)},
+				"dnld_install_sound": {testSoundProgress(SoundInstallStateDownloading, 0, 0)},
+				"get_sound_progress": {testSoundProgress(SoundInstallStateError, 0, 2)},
+			},
+		},
+		options: &Options{PollInterval: time.Millisecond},
+	}
+
+	ch, err := v.InstallSoundPack(context.Background(), path)
+	require.NoError(t, err)
+
+	var last SoundProgress
+	for p := range ch {
+		last = p
+	}
+
+	assert.Equal(t, SoundInstallStateError, last.State)
+	assert.Error(t, last.Err)
+}
+
+func TestVacuum_GetCurrentSound(t *testing.T) {
+	t.Parallel()
+
+	v := &Vacuum{
+		client: &mockClient{
+			rsp: []byte(`
+				{
+					"result": [{"sid_in_use": 3, "sid_version": 2, "location": "de", "bom": "A.03.0070", "language": "en"}],
+					"id": 1
+				}`,
+			),
+		},
+	}
+
+	got, err := v.GetCurrentSound()
+	require.NoError(t, err)
+	assert.Equal(t, &CurrentSound{SoundID: 3, Version: 2}, got)
+}
+
+func TestVacuum_Volume(t *testing.T) {
+	t.Parallel()
+
+	c := &mockClient{
+		rsps: map[string][][]byte{
+			"get_sound_volume": {[]byte(`{"result": [90], "id": 1}`)},
+		},
+	}
+	v := &Vacuum{client: c}
+
+	got, err := v.Volume()
+	require.NoError(t, err)
+	assert.Equal(t, 90, got)
+
+	require.NoError(t, v.SetVolume(30))
+	assert.JSONEq(t, `[30]`, string(c.reqs[1].Params))
+
+	assert.Error(t, v.SetVolume(101))
+	assert.Error(t, v.SetVolume(-1))
+
+	require.NoError(t, v.TestVolume())
+	assert.Equal(t, []string{"get_sound_volume", "change_sound_volume", "test_sound_volume"}, c.methods())
+}
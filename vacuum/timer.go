@@ -0,0 +1,353 @@
+package vacuum
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type TimerActionKind int
+
+const (
+	TimerActionFullClean TimerActionKind = iota
+	TimerActionSegmentClean
+)
+
+// TimerAction is the clean started by a Timer.
+type TimerAction struct {
+	Kind TimerActionKind
+	// Segments to clean when Kind is TimerActionSegmentClean, see GetRoomMapping.
+	Segments []int
+	// Repeat is the number of times the segments are cleaned, defaults to 1.
+	Repeat int
+	// FanPower of the clean, 0 uses the vacuum's current fan power.
+	FanPower int
+}
+
+// Timer is a cleaning schedule stored on the vacuum.
+type Timer struct {
+	// ID of the timer, this is assigned when the timer is created if not set.
+	ID       string
+	Enabled  bool
+	Schedule Cron
+	// Location is the timezone the Schedule is evaluated in, this is the vacuum's timezone or
+	// UTC if it could not be retrieved.
+	Location *time.Location
+	Action   TimerAction
+}
+
+// Next returns the next time after t the timer will run, in the timer's Location. The zero
+// time is returned if the timer is disabled.
+func (t *Timer) Next(after time.Time) time.Time {
+	if !t.Enabled {
+		return time.Time{}
+	}
+
+	loc := t.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	return t.Schedule.Next(after.In(loc))
+}
+
+// GetTimers retrieves the cleaning timers stored on the Vacuum. The timers are in the
+// Vacuum's timezone, or UTC if the firmware does not support timezones.
+func (v *Vacuum) GetTimers() ([]Timer, error) {
+	return v.getTimers("get_timer")
+}
+
+// SetTimer creates a cleaning timer on the Vacuum. If the timer does not have an ID one is
+// assigned and written to t.ID, so the timer can later be updated or deleted.
+func (v *Vacuum) SetTimer(t *Timer) error {
+	return v.setTimer("set_timer", "upd_timer", t)
+}
+
+// UpdateTimer enables or disables a cleaning timer.
+func (v *Vacuum) UpdateTimer(id string, enabled bool) error {
+	return v.updateTimer("upd_timer", id, enabled)
+}
+
+// DeleteTimer deletes a cleaning timer.
+func (v *Vacuum) DeleteTimer(id string) error {
+	return v.deleteTimer("del_timer", id)
+}
+
+// GetServerTimers retrieves the cleaning timers stored by the cloud for the Vacuum. The timers
+// are in the Vacuum's timezone, or UTC if the firmware does not support timezones.
+func (v *Vacuum) GetServerTimers() ([]Timer, error) {
+	return v.getTimers("get_server_timer")
+}
+
+// SetServerTimer creates a cleaning timer stored by the cloud. If the timer does not have an
+// ID one is assigned and written to t.ID.
+func (v *Vacuum) SetServerTimer(t *Timer) error {
+	return v.setTimer("set_server_timer", "upd_server_timer", t)
+}
+
+// UpdateServerTimer enables or disables a cleaning timer stored by the cloud.
+func (v *Vacuum) UpdateServerTimer(id string, enabled bool) error {
+	return v.updateTimer("upd_server_timer", id, enabled)
+}
+
+// DeleteServerTimer deletes a cleaning timer stored by the cloud.
+func (v *Vacuum) DeleteServerTimer(id string) error {
+	return v.deleteTimer("del_server_timer", id)
+}
+
+func (v *Vacuum) getTimers(method string) ([]Timer, error) {
+	// Timers are of the form [id, "on"|"off", [cron, [action, params]]].
+	rsp := make([][]json.RawMessage, 0)
+
+	err := v.do(method, nil, &rsp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Firmware without a timezone runs its timers in UTC, as Timer.Next assumes.
+	loc, err := v.Timezone()
+	if errors.Is(err, ErrNotSupported) {
+		loc = time.UTC
+	} else if err != nil {
+		return nil, err
+	}
+
+	timers := make([]Timer, len(rsp))
+
+	for i, raw := range rsp {
+		t, err := decodeTimer(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode timer: %w", err)
+		}
+
+		t.Location = loc
+		timers[i] = *t
+	}
+
+	return timers, nil
+}
+
+func (v *Vacuum) setTimer(setMethod, updMethod string, t *Timer) error {
+	if t.Schedule.IsZero() {
+		return errors.New("timer must have a schedule")
+	}
+
+	if t.ID == "" {
+		t.ID = strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond), 10)
+	}
+
+	action, err := encodeTimerAction(t.Action)
+	if err != nil {
+		return err
+	}
+
+	p, err := json.Marshal([]interface{}{
+		[]interface{}{t.ID, []interface{}{t.Schedule.String(), action}},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = v.doParams(setMethod, p)
+	if err != nil {
+		return err
+	}
+
+	// Timers are enabled when created.
+	if !t.Enabled {
+		return v.updateTimer(updMethod, t.ID, false)
+	}
+
+	return nil
+}
+
+func (v *Vacuum) updateTimer(method, id string, enabled bool) error {
+	state := "off"
+	if enabled {
+		state = "on"
+	}
+
+	p, err := json.Marshal([]string{id, state})
+	if err != nil {
+		return err
+	}
+
+	return v.doParams(method, p)
+}
+
+func (v *Vacuum) deleteTimer(method, id string) error {
+	p, err := json.Marshal([]string{id})
+	if err != nil {
+		return err
+	}
+
+	return v.doParams(method, p)
+}
+
+// timerActionParams are the params of a segment clean timer.
+type timerActionParams struct {
+	FanPower int `json:"fan_power,omitempty"`
+	// Segments are a comma separated list of segment IDs.
+	Segments string `json:"segments,omitempty"`
+	Repeat   int    `json:"repeat,omitempty"`
+}
+
+func decodeTimer(raw []json.RawMessage) (*Timer, error) {
+	if len(raw) < 3 {
+		return nil, ErrUnexpectedResponse
+	}
+
+	t := &Timer{}
+
+	var state string
+
+	for i, f := range []interface{}{&t.ID, &state} {
+		err := json.Unmarshal(raw[i], f)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	t.Enabled = state == "on"
+
+	schedule := make([]json.RawMessage, 0)
+
+	err := json.Unmarshal(raw[2], &schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(schedule) < 2 {
+		return nil, ErrUnexpectedResponse
+	}
+
+	var expr string
+
+	err = json.Unmarshal(schedule[0], &expr)
+	if err != nil {
+		return nil, err
+	}
+
+	t.Schedule, err = ParseCron(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	t.Action, err = decodeTimerAction(schedule[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// decodeTimerAction decodes an action of the form ["start_clean", params] where params is
+// either empty, the fan power or the details of a segment clean, which may be JSON encoded
+// as a string.
+func decodeTimerAction(raw json.RawMessage) (TimerAction, error) {
+	a := TimerAction{Kind: TimerActionFullClean}
+	action := make([]json.RawMessage, 0)
+
+	err := json.Unmarshal(raw, &action)
+	if err != nil {
+		return a, err
+	}
+
+	if len(action) < 2 {
+		return a, nil
+	}
+
+	var params interface{}
+
+	err = json.Unmarshal(action[1], &params)
+	if err != nil {
+		return a, err
+	}
+
+	switch p := params.(type) {
+	case float64:
+		a.FanPower = int(p)
+		return a, nil
+	case string:
+		if p == "" {
+			return a, nil
+		}
+
+		action[1] = json.RawMessage(p)
+	}
+
+	s := struct {
+		FanPower int             `json:"fan_power"`
+		Segments json.RawMessage `json:"segments"`
+		Repeat   int             `json:"repeat"`
+	}{}
+
+	err = json.Unmarshal(action[1], &s)
+	if err != nil {
+		return a, err
+	}
+
+	a.FanPower = s.FanPower
+	a.Repeat = s.Repeat
+
+	if len(s.Segments) == 0 {
+		return a, nil
+	}
+
+	a.Kind = TimerActionSegmentClean
+
+	// Segments are usually a comma separated string but may be a list.
+	var segments string
+
+	if json.Unmarshal(s.Segments, &segments) != nil {
+		err = json.Unmarshal(s.Segments, &a.Segments)
+		return a, err
+	}
+
+	for _, id := range strings.Split(segments, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(id))
+		if err != nil {
+			return a, fmt.Errorf("invalid segment %q", id)
+		}
+
+		a.Segments = append(a.Segments, n)
+	}
+
+	return a, nil
+}
+
+func encodeTimerAction(a TimerAction) ([]interface{}, error) {
+	switch a.Kind {
+	case TimerActionFullClean:
+		if a.FanPower == 0 {
+			return []interface{}{"start_clean", ""}, nil
+		}
+
+		return []interface{}{"start_clean", a.FanPower}, nil
+	case TimerActionSegmentClean:
+		if len(a.Segments) == 0 {
+			return nil, errors.New("segment clean timer must have at least one segment")
+		}
+
+		segments := make([]string, len(a.Segments))
+		for i, s := range a.Segments {
+			segments[i] = strconv.Itoa(s)
+		}
+
+		repeat := a.Repeat
+		if repeat == 0 {
+			repeat = 1
+		}
+
+		return []interface{}{"start_clean", timerActionParams{
+			FanPower: a.FanPower,
+			Segments: strings.Join(segments, ","),
+			Repeat:   repeat,
+		}}, nil
+	}
+
+	return nil, fmt.Errorf("unknown timer action %d", a.Kind)
+}
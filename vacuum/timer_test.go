@@ -0,0 +1,232 @@
+package vacuum
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testTimezone = []byte(`{"result": ["Europe/Berlin"], "id": 1}`)
+
+func TestVacuum_GetTimers(t *testing.T) {
+	t.Parallel()
+
+	v := &Vacuum{
+		client: &mockClient{
+			rsps: map[string][][]byte{
+				"get_timezone": {testTimezone},
+				"get_timer": {[]byte(`
+					{
+						"result": [
+							["1498595904821", "on", ["32 20 * * 1,2,3,4,5", ["start_clean", ""]]],
+							["1498595904822", "off", ["0 9 * * 6", ["start_clean", 104]]],
+							["1498595904823", "on", ["0 10 * * 0", ["start_clean", "{\"fan_power\":102,\"segments\":\"16,17\",\"repeat\":2}"]]],
+							["1498595904824", "on", ["0 11 * * 0", ["start_clean", {"fan_power": 101, "segments": [18]}]]]
+						],
+						"id": 1
+					}`,
+				)},
+			},
+		},
+	}
+
+	got, err := v.GetTimers()
+	require.NoError(t, err)
+	require.Len(t, got, 4)
+
+	assert.Equal(t, "1498595904821", got[0].ID)
+	assert.True(t, got[0].Enabled)
+	assert.Equal(t, "32 20 * * 1,2,3,4,5", got[0].Schedule.String())
+	assert.Equal(t, "Europe/Berlin", got[0].Location.String())
+	assert.Equal(t, TimerAction{Kind: TimerActionFullClean}, got[0].Action)
+
+	assert.False(t, got[1].Enabled)
+	assert.Equal(t, TimerAction{Kind: TimerActionFullClean, FanPower: 104}, got[1].Action)
+
+	assert.Equal(t, TimerAction{
+		Kind:     TimerActionSegmentClean,
+		Segments: []int{16, 17},
+		Repeat:   2,
+		FanPower: 102,
+	}, got[2].Action)
+
+	assert.Equal(t, TimerAction{
+		Kind:     TimerActionSegmentClean,
+		Segments: []int{18},
+		FanPower: 101,
+	}, got[3].Action)
+
+	// Monday 6th of June 2022 at 20:00 UTC is 22:00 in Berlin, so the next run is Tuesday.
+	next := got[0].Next(time.Date(2022, 6, 6, 20, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2022, 6, 7, 20, 32, 0, 0, got[0].Location), next)
+	assert.True(t, got[1].Next(time.Now()).IsZero())
+}
+
+func TestVacuum_SetTimer(t *testing.T) {
+	t.Parallel()
+
+	schedule, err := WeeklyCron(9, 30, time.Saturday)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		timer       Timer
+		wantParams  string
+		wantMethods []string
+	}{
+		"full clean": {
+			timer: Timer{
+				ID:       "1",
+				Enabled:  true,
+				Schedule: schedule,
+			},
+			wantParams:  `[["1", ["30 9 * * 6", ["start_clean", ""]]]]`,
+			wantMethods: []string{"set_timer"},
+		},
+		"disabled segment clean": {
+			timer: Timer{
+				ID:       "2",
+				Schedule: schedule,
+				Action: TimerAction{
+					Kind:     TimerActionSegmentClean,
+					Segments: []int{16, 17},
+					FanPower: 102,
+				},
+			},
+			wantParams:  `[["2", ["30 9 * * 6", ["start_clean", {"fan_power": 102, "segments": "16,17", "repeat": 1}]]]]`,
+			wantMethods: []string{"set_timer", "upd_timer"},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &mockClient{}
+			v := &Vacuum{client: c}
+
+			err := v.SetTimer(&tt.timer)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantMethods, c.methods())
+			assert.JSONEq(t, tt.wantParams, string(c.reqs[0].Params))
+
+			if len(c.reqs) > 1 {
+				assert.JSONEq(t, `["2", "off"]`, string(c.reqs[1].Params))
+			}
+		})
+	}
+}
+
+func TestVacuum_GetTimers_NoTimezone(t *testing.T) {
+	t.Parallel()
+
+	v := &Vacuum{
+		client: &mockClient{
+			rsps: map[string][][]byte{
+				"get_timezone": {[]byte(`{"error": {"code": -32601, "message": "Method not found."}, "id": 1}`)},
+				"get_timer":    {[]byte(`{"result": [["1498595904821", "on", ["0 9 * * 6", ["start_clean", ""]]]], "id": 1}`)},
+			},
+		},
+	}
+
+	got, err := v.GetTimers()
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, time.UTC, got[0].Location)
+}
+
+func TestVacuum_GetTimers_TimezoneError(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string][]byte{
+		"unknown zone": []byte(`{"result": ["Mars/Olympus_Mons"], "id": 1}`),
+		"device error": []byte(`{"error": {"code": -1, "message": "timeout"}, "id": 1}`),
+	}
+
+	for name, rsp := range tests {
+		rsp := rsp
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			v := &Vacuum{
+				client: &mockClient{
+					rsps: map[string][][]byte{
+						"get_timezone": {rsp},
+						"get_timer":    {[]byte(`{"result": [["1498595904821", "on", ["0 9 * * 6", ["start_clean", ""]]]], "id": 1}`)},
+					},
+				},
+			}
+
+			_, err := v.GetTimers()
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestVacuum_SetTimer_AssignsID(t *testing.T) {
+	t.Parallel()
+
+	schedule, err := WeeklyCron(9, 30)
+	require.NoError(t, err)
+
+	v := &Vacuum{client: &mockClient{}}
+	timer := &Timer{Enabled: true, Schedule: schedule}
+
+	err = v.SetTimer(timer)
+	require.NoError(t, err)
+	assert.NotEmpty(t, timer.ID)
+
+	err = v.SetTimer(&Timer{})
+	assert.Error(t, err)
+}
+
+func TestVacuum_UpdateDeleteTimer(t *testing.T) {
+	t.Parallel()
+
+	c := &mockClient{}
+	v := &Vacuum{client: c}
+
+	require.NoError(t, v.UpdateTimer("1", true))
+	require.NoError(t, v.DeleteTimer("1"))
+	require.Equal(t, []string{"upd_timer", "del_timer"}, c.methods())
+	assert.JSONEq(t, `["1", "on"]`, string(c.reqs[0].Params))
+	assert.JSONEq(t, `["1"]`, string(c.reqs[1].Params))
+}
+
+func TestVacuum_ServerTimers(t *testing.T) {
+	t.Parallel()
+
+	schedule, err := WeeklyCron(7, 0, time.Monday)
+	require.NoError(t, err)
+
+	c := &mockClient{
+		rsps: map[string][][]byte{
+			"get_timezone": {testTimezone},
+			"get_server_timer": {[]byte(`
+				{
+					"result": [["1542715946806", "on", ["0 7 * * 1", ["start_clean", ""]]]],
+					"id": 1
+				}`,
+			)},
+		},
+	}
+	v := &Vacuum{client: c}
+
+	got, err := v.GetServerTimers()
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "1542715946806", got[0].ID)
+
+	require.NoError(t, v.SetServerTimer(&Timer{ID: "1", Schedule: schedule}))
+	require.NoError(t, v.UpdateServerTimer("1", true))
+	require.NoError(t, v.DeleteServerTimer("1"))
+
+	assert.Equal(t, []string{
+		"get_server_timer", "get_timezone",
+		"set_server_timer", "upd_server_timer",
+		"upd_server_timer",
+		"del_server_timer",
+	}, c.methods())
+}
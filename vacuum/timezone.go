@@ -0,0 +1,44 @@
+package vacuum
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Timezone retrieves the timezone of the Vacuum, this is used to evaluate its timers.
+func (v *Vacuum) Timezone() (*time.Location, error) {
+	rsp := make([]string, 0)
+
+	err := v.do("get_timezone", nil, &rsp)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rsp) != 1 {
+		return nil, ErrUnexpectedResponse
+	}
+
+	return time.LoadLocation(rsp[0])
+}
+
+// SetTimezone sets the timezone of the Vacuum. The name must be an IANA timezone, e.g.
+// Europe/London, so the vacuum can follow daylight saving time changes.
+func (v *Vacuum) SetTimezone(name string) error {
+	// LoadLocation accepts "" and "Local" which are meaningless to the vacuum.
+	if name == "" || name == "Local" {
+		return fmt.Errorf("invalid timezone %q", name)
+	}
+
+	_, err := time.LoadLocation(name)
+	if err != nil {
+		return fmt.Errorf("invalid timezone %q: %w", name, err)
+	}
+
+	p, err := json.Marshal([]string{name})
+	if err != nil {
+		return err
+	}
+
+	return v.doParams("set_timezone", p)
+}
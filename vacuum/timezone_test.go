@@ -0,0 +1,36 @@
+package vacuum
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVacuum_Timezone(t *testing.T) {
+	t.Parallel()
+
+	v := &Vacuum{client: &mockClient{rsp: testTimezone}}
+
+	got, err := v.Timezone()
+	require.NoError(t, err)
+	assert.Equal(t, "Europe/Berlin", got.String())
+}
+
+func TestVacuum_SetTimezone(t *testing.T) {
+	t.Parallel()
+
+	c := &mockClient{}
+	v := &Vacuum{client: c}
+
+	err := v.SetTimezone("Europe/London")
+	require.NoError(t, err)
+	assert.JSONEq(t, `["Europe/London"]`, string(c.reqs[0].Params))
+
+	for _, name := range []string{"", "Local", "Europe/Nowhere"} {
+		err = v.SetTimezone(name)
+		assert.Error(t, err, name)
+	}
+
+	assert.Len(t, c.reqs, 1)
+}
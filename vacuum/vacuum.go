@@ -1,7 +1,11 @@
 package vacuum
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
+	"sync"
 	"time"
 
 	"github.com/l-ross/xiaomi/miio"
@@ -9,19 +13,97 @@ import (
 
 var (
 	ErrUnexpectedResponse = errors.New("unexpected response")
+	ErrNotSupported       = errors.New("not supported")
 )
 
+// DefaultPollInterval is how often the Vacuum's status is polled while waiting on it.
+const DefaultPollInterval = time.Second
+
 type Vacuum struct {
 	client iClient
 
+	// mu guards id and the use of client.
+	mu sync.Mutex
 	id int64
+
+	// cacheMu guards the details of the Vacuum which are cached as they do not change.
+	cacheMu         sync.Mutex
+	modelName       string
+	firmwareVersion string
+	features        FeatureSet
+	// featuresErr is set if the firmware cannot report its features.
+	featuresErr error
+
+	options *Options
+}
+
+type Options struct {
+	// MapResolver resolves the names of maps to the URL they can be downloaded from.
+	MapResolver MapResolver
+	// HTTPClient is used to download maps.
+	HTTPClient *http.Client
+	// PollInterval is how often the Vacuum's status is polled while waiting on it.
+	PollInterval time.Duration
+}
+
+type Option func(*Options) error
+
+// defaultOptions defines the default Options for a Vacuum. To override these provide the appropriate
+// Option when calling New.
+func defaultOptions() *Options {
+	return &Options{
+		HTTPClient:   http.DefaultClient,
+		PollInterval: DefaultPollInterval,
+	}
+}
+
+// SetMapResolver sets the MapResolver used to download maps.
+func SetMapResolver(r MapResolver) Option {
+	return func(o *Options) error {
+		o.MapResolver = r
+		return nil
+	}
+}
+
+// SetHTTPClient sets the HTTP client used to download maps.
+func SetHTTPClient(c *http.Client) Option {
+	return func(o *Options) error {
+		if c == nil {
+			return errors.New("http client must not be nil")
+		}
+
+		o.HTTPClient = c
+		return nil
+	}
+}
+
+// SetPollInterval sets how often the Vacuum's status is polled while waiting on it, e.g. by
+// GoToAndWait.
+func SetPollInterval(d time.Duration) Option {
+	return func(o *Options) error {
+		if d <= 0 {
+			return errors.New("poll interval must be positive")
+		}
+
+		o.PollInterval = d
+		return nil
+	}
 }
 
 type iClient interface {
 	Send(payload []byte) ([]byte, error)
 }
 
-func New(c *miio.Client) (*Vacuum, error) {
+func New(c *miio.Client, opts ...Option) (*Vacuum, error) {
+	o := defaultOptions()
+
+	for _, opt := range opts {
+		err := opt(o)
+		if err != nil {
+			return nil, fmt.Errorf("error setting option: %w", err)
+		}
+	}
+
 	if !c.Connected() {
 		err := c.Connect()
 		if err != nil {
@@ -30,19 +112,68 @@ func New(c *miio.Client) (*Vacuum, error) {
 	}
 
 	v := &Vacuum{
-		client: c,
-		id:     time.Now().Unix(),
+		client:  c,
+		id:      time.Now().Unix(),
+		options: o,
 	}
 
 	return v, nil
 }
 
+// getOptions returns the Options of the Vacuum, falling back to the defaults.
+func (v *Vacuum) getOptions() *Options {
+	if v.options == nil {
+		return defaultOptions()
+	}
+
+	return v.options
+}
+
+// getPollInterval returns the interval at which to poll the Vacuum's status, DefaultPollInterval
+// is used if Options.PollInterval is unset.
+func (v *Vacuum) getPollInterval() time.Duration {
+	if d := v.getOptions().PollInterval; d > 0 {
+		return d
+	}
+
+	return DefaultPollInterval
+}
+
 type mockClient struct {
 	rsp []byte
 	err error
+
+	// rsps optionally defines the responses for a given method, these are returned in order
+	// with the final response being repeated.
+	rsps map[string][][]byte
+	// reqs records each request sent via the mock.
+	reqs []*request
+	// hook is optionally called with each request before it is responded to.
+	hook func(req *request)
 }
 
 func (c *mockClient) Send(payload []byte) ([]byte, error) {
+	req := &request{}
+	err := json.Unmarshal(payload, req)
+	if err != nil {
+		return nil, err
+	}
+
+	c.reqs = append(c.reqs, req)
+
+	if c.hook != nil {
+		c.hook(req)
+	}
+
+	if rsps := c.rsps[req.Method]; len(rsps) > 0 {
+		rsp := rsps[0]
+		if len(rsps) > 1 {
+			c.rsps[req.Method] = rsps[1:]
+		}
+
+		return rsp, c.err
+	}
+
 	// If the mock doesn't define a rsp then just return a default.
 	if c.rsp == nil {
 		c.rsp = []byte(`
@@ -55,3 +186,13 @@ func (c *mockClient) Send(payload []byte) ([]byte, error) {
 
 	return c.rsp, c.err
 }
+
+// methods returns the methods of the requests sent via the mock, in order.
+func (c *mockClient) methods() []string {
+	m := make([]string, len(c.reqs))
+	for i, r := range c.reqs {
+		m[i] = r.Method
+	}
+
+	return m
+}
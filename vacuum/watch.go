@@ -0,0 +1,256 @@
+package vacuum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultWatchInterval is how often the Vacuum's status is polled by Watch when no interval
+// is provided.
+const DefaultWatchInterval = time.Minute
+
+// watchActiveSpeedup is how much faster the status is polled while the vacuum is active.
+const watchActiveSpeedup = 4
+
+// watchMinActiveInterval is the shortest interval the status is polled at while the vacuum is
+// active, unless the interval provided to Watch is shorter.
+const watchMinActiveInterval = time.Second
+
+// BatteryLevels are the battery levels, in percent, for which EventBatteryLevelCrossed is sent.
+// These are read when Watch is called.
+var BatteryLevels = []int{10, 20, 50, 80, 100}
+
+type EventKind int
+
+const (
+	// EventStateChanged is sent when the State of the vacuum changes.
+	EventStateChanged EventKind = iota
+	// EventErrorRaised is sent when the vacuum reports a new error.
+	EventErrorRaised
+	// EventErrorCleared is sent when the vacuum no longer reports an error.
+	EventErrorCleared
+	// EventBatteryLevelCrossed is sent when the battery rises to, or falls below, one of
+	// the BatteryLevels.
+	EventBatteryLevelCrossed
+	// EventCleaningStarted is sent when a clean is started.
+	EventCleaningStarted
+	// EventCleaningFinished is sent when a clean has finished, a summary of the clean is
+	// available in the Clean field.
+	EventCleaningFinished
+	// EventDocked is sent when the vacuum returns to its dock.
+	EventDocked
+	// EventPollError is sent when the status could not be retrieved, the error is available
+	// in the Err field. Watching continues after the error.
+	EventPollError
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventStateChanged:
+		return "StateChanged"
+	case EventErrorRaised:
+		return "ErrorRaised"
+	case EventErrorCleared:
+		return "ErrorCleared"
+	case EventBatteryLevelCrossed:
+		return "BatteryLevelCrossed"
+	case EventCleaningStarted:
+		return "CleaningStarted"
+	case EventCleaningFinished:
+		return "CleaningFinished"
+	case EventDocked:
+		return "Docked"
+	case EventPollError:
+		return "PollError"
+	}
+
+	return fmt.Sprintf("EventKind(%d)", int(k))
+}
+
+type Event struct {
+	Kind EventKind
+	// Time the status was polled.
+	Time time.Time
+	// Status and Previous are the snapshots the event was derived from, they are nil for
+	// EventPollError.
+	Status   *Status
+	Previous *Status
+
+	// BatteryLevel is the level crossed, set for EventBatteryLevelCrossed.
+	BatteryLevel int
+	// Clean summarises the finished clean, set for EventCleaningFinished. Start is the time
+	// of the matching EventCleaningStarted, it is zero if the clean started before watching.
+	// Only the other fields reported by the status are set.
+	Clean *CleanRecord
+	// Err is set for EventPollError.
+	Err error
+}
+
+// Watch polls the status of the Vacuum and sends the events derived from successive
+// snapshots. The first snapshot is used as a baseline and does not produce events.
+//
+// The status is polled every interval, or DefaultWatchInterval if it is zero, and more
+// often while the vacuum is cleaning or travelling. The channel is closed when ctx is done.
+func (v *Vacuum) Watch(ctx context.Context, interval time.Duration) (<-chan Event, error) {
+	if interval < 0 {
+		return nil, errors.New("interval must not be negative")
+	}
+
+	if interval == 0 {
+		interval = DefaultWatchInterval
+	}
+
+	levels := make([]int, len(BatteryLevels))
+	copy(levels, BatteryLevels)
+
+	activeInterval := watchActiveInterval(interval)
+
+	ch := make(chan Event)
+
+	go func() {
+		defer close(ch)
+
+		var (
+			prev       *Status
+			cleanStart time.Time
+		)
+
+		timer := time.NewTimer(0)
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				return
+			}
+
+			now := time.Now()
+
+			s, err := v.Status()
+			if err != nil {
+				select {
+				case ch <- Event{Kind: EventPollError, Time: now, Err: err}:
+				case <-ctx.Done():
+					return
+				}
+
+				timer.Reset(interval)
+				continue
+			}
+
+			if prev != nil {
+				for _, e := range diffStatus(prev, s, now, levels) {
+					switch e.Kind {
+					case EventCleaningStarted:
+						cleanStart = now
+					case EventCleaningFinished:
+						e.Clean.Start = cleanStart
+						cleanStart = time.Time{}
+					}
+
+					select {
+					case ch <- e:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+
+			prev = s
+
+			next := interval
+			if isActive(s) {
+				next = activeInterval
+			}
+
+			timer.Reset(next)
+		}
+	}()
+
+	return ch, nil
+}
+
+// watchActiveInterval returns the interval the status is polled at while the vacuum is active.
+func watchActiveInterval(interval time.Duration) time.Duration {
+	active := interval / watchActiveSpeedup
+	if active < watchMinActiveInterval {
+		active = watchMinActiveInterval
+	}
+
+	if active > interval {
+		active = interval
+	}
+
+	return active
+}
+
+// isActive reports whether the vacuum is cleaning or travelling.
+func isActive(s *Status) bool {
+	switch s.State {
+	case StatusCodeReturningDock, StatusCodeDocking, StatusCodeGoTo:
+		return true
+	}
+
+	return inCleaningSession(s)
+}
+
+// inCleaningSession reports whether the vacuum is part way through a clean. A clean continues
+// while the vacuum is paused or returns to the dock to recharge.
+func inCleaningSession(s *Status) bool {
+	return s.IsCleaning() || s.InCleaning
+}
+
+// diffStatus returns the events derived from the change between two snapshots.
+func diffStatus(prev, cur *Status, t time.Time, levels []int) []Event {
+	events := make([]Event, 0)
+
+	add := func(e Event) {
+		e.Time = t
+		e.Status = cur
+		e.Previous = prev
+		events = append(events, e)
+	}
+
+	if cur.State != prev.State {
+		add(Event{Kind: EventStateChanged})
+	}
+
+	if cur.ErrorCode != prev.ErrorCode {
+		if prev.ErrorCode != ErrorCodeNoError && cur.ErrorCode == ErrorCodeNoError {
+			add(Event{Kind: EventErrorCleared})
+		} else if cur.ErrorCode != ErrorCodeNoError {
+			add(Event{Kind: EventErrorRaised})
+		}
+	}
+
+	switch {
+	case !inCleaningSession(prev) && inCleaningSession(cur):
+		add(Event{Kind: EventCleaningStarted})
+	case inCleaningSession(prev) && !inCleaningSession(cur):
+		add(Event{
+			Kind: EventCleaningFinished,
+			Clean: &CleanRecord{
+				End:       t,
+				Duration:  cur.CleanTime,
+				Area:      cur.CleanArea,
+				Error:     cur.ErrorCode,
+				Completed: cur.ErrorCode == ErrorCodeNoError,
+			},
+		})
+	}
+
+	if !prev.IsDocked() && cur.IsDocked() {
+		add(Event{Kind: EventDocked})
+	}
+
+	for _, level := range levels {
+		if (prev.Battery < level) != (cur.Battery < level) {
+			add(Event{Kind: EventBatteryLevelCrossed, BatteryLevel: level})
+		}
+	}
+
+	return events
+}
@@ -0,0 +1,139 @@
+package vacuum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testWatchStatus(state StatusCode, errorCode ErrorCode, battery, inCleaning int) []byte {
+	return []byte(fmt.Sprintf(`
+		{
+			"result": [{
+					"state": %d,
+					"error_code": %d,
+					"battery": %d,
+					"in_cleaning": %d,
+					"clean_time": 1200,
+					"clean_area": 20000000
+				}
+			],
+			"id": 1
+		}`,
+		state, errorCode, battery, inCleaning,
+	))
+}
+
+func TestVacuum_Watch(t *testing.T) {
+	t.Parallel()
+
+	v := &Vacuum{
+		client: &mockClient{
+			rsps: map[string][][]byte{
+				"get_status": {
+					testWatchStatus(StatusCodeCharging, ErrorCodeNoError, 100, 0),
+					testWatchStatus(StatusCodeCleaning, ErrorCodeNoError, 85, 1),
+					testWatchStatus(StatusCodeInError, ErrorCodeMainBrushBlocked, 75, 1),
+					testWatchStatus(StatusCodeReturningDock, ErrorCodeNoError, 75, 1),
+					testWatchStatus(StatusCodeCharging, ErrorCodeNoError, 45, 0),
+				},
+			},
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := v.Watch(ctx, time.Millisecond)
+	require.NoError(t, err)
+
+	events := make([]Event, 0)
+	for e := range ch {
+		events = append(events, e)
+		if len(events) == 12 {
+			cancel()
+		}
+	}
+
+	kinds := make([]EventKind, len(events))
+	for i, e := range events {
+		kinds[i] = e.Kind
+	}
+
+	assert.Equal(t, []EventKind{
+		EventStateChanged, EventCleaningStarted, EventBatteryLevelCrossed,
+		EventStateChanged, EventErrorRaised, EventBatteryLevelCrossed,
+		EventStateChanged, EventErrorCleared,
+		EventStateChanged, EventCleaningFinished, EventDocked, EventBatteryLevelCrossed,
+	}, kinds)
+
+	assert.Equal(t, 100, events[2].BatteryLevel)
+	assert.Equal(t, ErrorCodeMainBrushBlocked, events[4].Status.ErrorCode)
+	assert.Equal(t, StatusCodeReturningDock, events[6].Status.State)
+	assert.Equal(t, events[1].Time, events[9].Clean.Start)
+	assert.Equal(t, events[9].Time, events[9].Clean.End)
+	assert.Equal(t, 20*time.Minute, events[9].Clean.Duration)
+	assert.Equal(t, 20.0, events[9].Clean.Area)
+	assert.Equal(t, 50, events[11].BatteryLevel)
+}
+
+func TestVacuum_Watch_PollError(t *testing.T) {
+	t.Parallel()
+
+	v := &Vacuum{
+		client: &mockClient{
+			err: errors.New("timeout"),
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := v.Watch(ctx, time.Millisecond)
+	require.NoError(t, err)
+
+	e := <-ch
+	assert.Equal(t, EventPollError, e.Kind)
+	assert.EqualError(t, e.Err, "timeout")
+
+	// Watching continues after an error.
+	e = <-ch
+	assert.Equal(t, EventPollError, e.Kind)
+}
+
+func TestWatchActiveInterval(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 15*time.Second, watchActiveInterval(time.Minute))
+	assert.Equal(t, time.Second, watchActiveInterval(2*time.Second))
+	assert.Equal(t, time.Millisecond, watchActiveInterval(time.Millisecond))
+	assert.Equal(t, time.Nanosecond, watchActiveInterval(time.Nanosecond))
+}
+
+func TestIsActive(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		status Status
+		want   bool
+	}{
+		"charging":  {status: Status{State: StatusCodeCharging}},
+		"cleaning":  {status: Status{State: StatusCodeRoomClean}, want: true},
+		"paused":    {status: Status{State: StatusCodePaused, InCleaning: true}, want: true},
+		"returning": {status: Status{State: StatusCodeReturningDock}, want: true},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, isActive(&tt.status))
+		})
+	}
+}
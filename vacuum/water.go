@@ -0,0 +1,195 @@
+package vacuum
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type WaterFlow int
+
+const (
+	WaterFlowOff WaterFlow = iota
+	WaterFlowLow
+	WaterFlowMedium
+	WaterFlowHigh
+	// WaterFlowCustom uses the per room water flows set in the app.
+	WaterFlowCustom
+)
+
+func (w WaterFlow) String() string {
+	switch w {
+	case WaterFlowOff:
+		return "Off"
+	case WaterFlowLow:
+		return "Low"
+	case WaterFlowMedium:
+		return "Medium"
+	case WaterFlowHigh:
+		return "High"
+	case WaterFlowCustom:
+		return "Custom"
+	}
+
+	return fmt.Sprintf("WaterFlow(%d)", int(w))
+}
+
+// waterFlowModes maps each WaterFlow to the water box mode sent to the vacuum.
+var waterFlowModes = map[WaterFlow]int{
+	WaterFlowOff:    200,
+	WaterFlowLow:    201,
+	WaterFlowMedium: 202,
+	WaterFlowHigh:   203,
+	WaterFlowCustom: 204,
+}
+
+type MopMode int
+
+const (
+	MopModeStandard MopMode = iota
+	MopModeDeep
+	MopModeDeepPlus
+	// MopModeCustom uses the per room mop modes set in the app.
+	MopModeCustom
+)
+
+func (m MopMode) String() string {
+	switch m {
+	case MopModeStandard:
+		return "Standard"
+	case MopModeDeep:
+		return "Deep"
+	case MopModeDeepPlus:
+		return "DeepPlus"
+	case MopModeCustom:
+		return "Custom"
+	}
+
+	return fmt.Sprintf("MopMode(%d)", int(m))
+}
+
+// mopModes maps each MopMode to the mop mode sent to the vacuum.
+var mopModes = map[MopMode]int{
+	MopModeStandard: 300,
+	MopModeDeep:     301,
+	MopModeCustom:   302,
+	MopModeDeepPlus: 303,
+}
+
+// GetWaterBoxCustomMode retrieves the flow of water used when mopping.
+func (v *Vacuum) GetWaterBoxCustomMode() (WaterFlow, error) {
+	err := v.requireMethod("get_water_box_custom_mode")
+	if err != nil {
+		return 0, err
+	}
+
+	rsp := make([]int, 0)
+
+	err = v.do("get_water_box_custom_mode", nil, &rsp)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(rsp) != 1 {
+		return 0, ErrUnexpectedResponse
+	}
+
+	for w, mode := range waterFlowModes {
+		if mode == rsp[0] {
+			return w, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: unknown water box mode %d", ErrUnexpectedResponse, rsp[0])
+}
+
+// SetWaterBoxCustomMode sets the flow of water used when mopping, ErrNotSupported is returned
+// if the flow is not supported by the Vacuum's model. DefaultModel is used if the model
+// cannot be retrieved.
+func (v *Vacuum) SetWaterBoxCustomMode(flow WaterFlow) error {
+	mode, ok := waterFlowModes[flow]
+	if !ok {
+		return fmt.Errorf("unknown water flow %s", flow)
+	}
+
+	m := v.modelOrDefault()
+	if !m.Supports("set_water_box_custom_mode") || !m.SupportsWaterFlow(flow) {
+		return fmt.Errorf("%w: water flow %s on %s", ErrNotSupported, flow, m.Name)
+	}
+
+	p, err := json.Marshal([]int{mode})
+	if err != nil {
+		return err
+	}
+
+	return v.doParams("set_water_box_custom_mode", p)
+}
+
+// GetMopMode retrieves the route taken when mopping.
+func (v *Vacuum) GetMopMode() (MopMode, error) {
+	err := v.requireMethod("get_mop_mode")
+	if err != nil {
+		return 0, err
+	}
+
+	rsp := make([]int, 0)
+
+	err = v.do("get_mop_mode", nil, &rsp)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(rsp) != 1 {
+		return 0, ErrUnexpectedResponse
+	}
+
+	for m, mode := range mopModes {
+		if mode == rsp[0] {
+			return m, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: unknown mop mode %d", ErrUnexpectedResponse, rsp[0])
+}
+
+// SetMopMode sets the route taken when mopping, deeper modes take a denser route.
+// ErrNotSupported is returned if the mode is not supported by the Vacuum's model.
+// DefaultModel is used if the model cannot be retrieved.
+func (v *Vacuum) SetMopMode(mode MopMode) error {
+	code, ok := mopModes[mode]
+	if !ok {
+		return fmt.Errorf("unknown mop mode %s", mode)
+	}
+
+	m := v.modelOrDefault()
+	if !m.Supports("set_mop_mode") || !m.SupportsMopMode(mode) {
+		return fmt.Errorf("%w: mop mode %s on %s", ErrNotSupported, mode, m.Name)
+	}
+
+	p, err := json.Marshal([]int{code})
+	if err != nil {
+		return err
+	}
+
+	return v.doParams("set_mop_mode", p)
+}
+
+// MopAttached reports whether the mop is attached.
+func (s *Status) MopAttached() bool {
+	return s.WaterBoxCarriageStatus
+}
+
+// WaterTankFitted reports whether the water tank is fitted.
+func (s *Status) WaterTankFitted() bool {
+	return s.WaterBoxStatus
+}
+
+// CanMop reports whether the Vacuum is ready to mop, i.e. both the mop is attached and the
+// water tank fitted.
+func (v *Vacuum) CanMop() (bool, error) {
+	s, err := v.Status()
+	if err != nil {
+		return false, err
+	}
+
+	return s.MopAttached() && s.WaterTankFitted(), nil
+}
@@ -0,0 +1,84 @@
+package vacuum
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVacuum_WaterBoxCustomMode(t *testing.T) {
+	t.Parallel()
+
+	c := &mockClient{
+		rsps: map[string][][]byte{
+			"get_water_box_custom_mode": {[]byte(`{"result": [202], "id": 1}`)},
+		},
+	}
+	v := &Vacuum{client: c, modelName: "roborock.vacuum.a27"}
+
+	got, err := v.GetWaterBoxCustomMode()
+	require.NoError(t, err)
+	assert.Equal(t, WaterFlowMedium, got)
+
+	require.NoError(t, v.SetWaterBoxCustomMode(WaterFlowHigh))
+	assert.JSONEq(t, `[203]`, string(c.reqs[1].Params))
+
+	assert.Error(t, v.SetWaterBoxCustomMode(WaterFlow(10)))
+}
+
+func TestVacuum_MopMode(t *testing.T) {
+	t.Parallel()
+
+	c := &mockClient{
+		rsps: map[string][][]byte{
+			"get_mop_mode": {[]byte(`{"result": [303], "id": 1}`)},
+		},
+	}
+	v := &Vacuum{client: c, modelName: "roborock.vacuum.a27"}
+
+	got, err := v.GetMopMode()
+	require.NoError(t, err)
+	assert.Equal(t, MopModeDeepPlus, got)
+
+	require.NoError(t, v.SetMopMode(MopModeDeep))
+	assert.JSONEq(t, `[301]`, string(c.reqs[1].Params))
+}
+
+func TestVacuum_CanMop(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		carriage int
+		waterBox int
+		want     bool
+	}{
+		"ready":        {carriage: 1, waterBox: 1, want: true},
+		"no mop":       {carriage: 0, waterBox: 1, want: false},
+		"no water box": {carriage: 1, waterBox: 0, want: false},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			v := &Vacuum{
+				client: &mockClient{
+					rsp: []byte(fmt.Sprintf(`
+						{
+							"result": [{"state": 8, "water_box_carriage_status": %d, "water_box_status": %d}],
+							"id": 1
+						}`,
+						tt.carriage, tt.waterBox,
+					)),
+				},
+			}
+
+			got, err := v.CanMop()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}